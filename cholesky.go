@@ -0,0 +1,110 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cholesky przechowuje rozkład symetrycznej, dodatnio określonej macierzy A w postaci A = L*L^T,
+// gdzie L jest macierzą dolnotrójkątną z dodatnimi elementami na przekątnej.
+type Cholesky struct {
+	L Matrix
+}
+
+// Cholesky zwraca rozkład Cholesky'ego macierzy oraz błąd (jeśli istnieje).
+// Błąd jest zwracany, gdy macierz nie jest kwadratowa, symetryczna lub dodatnio określona.
+func (m Matrix) Cholesky() (*Cholesky, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !m.isSquare() {
+		return nil, fmt.Errorf("Nie można rozłożyć macierzy niekwadratowej")
+	}
+
+	n, _ := m.Dim()
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			scale := math.Max(1, math.Max(math.Abs(m[i][j]), math.Abs(m[j][i])))
+			if math.Abs(m[i][j]-m[j][i]) > 1e-10*scale {
+				return nil, fmt.Errorf("Macierz nie jest symetryczna")
+			}
+		}
+	}
+
+	l := make(Matrix, n)
+	for i := range l {
+		l[i] = make(Vector, n)
+	}
+
+	for j := 0; j < n; j++ {
+		d := m[j][j]
+		for k := 0; k < j; k++ {
+			d -= l[j][k] * l[j][k]
+		}
+
+		// Niedodatni element na przekątnej oznacza, że macierz nie jest dodatnio określona
+		if d <= 0 || math.IsNaN(d) {
+			return nil, fmt.Errorf("Macierz nie jest dodatnio określona")
+		}
+		l[j][j] = math.Sqrt(d)
+
+		for i := j + 1; i < n; i++ {
+			s := m[i][j]
+			for k := 0; k < j; k++ {
+				s -= l[i][k] * l[j][k]
+			}
+			l[i][j] = s / l[j][j]
+		}
+	}
+
+	return &Cholesky{L: l}, nil
+}
+
+// Det zwraca wyznacznik macierzy, z której powstał rozkład.
+func (f *Cholesky) Det() float64 {
+	det := 1.0
+	for i := range f.L {
+		det *= f.L[i][i] * f.L[i][i]
+	}
+	return det
+}
+
+// Solve otrzymuje macierz prawych stron B.
+// Rozwiązuje układ A * X = B, rozwiązując kolejno L * Y = B i L^T * X = Y. Zwraca X oraz błąd (jeśli istnieje).
+func (f *Cholesky) Solve(b Matrix) (Matrix, error) {
+	n := len(f.L)
+
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	if rows, _ := b.Dim(); rows != n {
+		return nil, fmt.Errorf("Liczba wierszy prawej strony musi być równa rozmiarowi macierzy")
+	}
+
+	x := b.copy()
+	_, cols := b.Dim()
+
+	for c := 0; c < cols; c++ {
+
+		// L * Y = B
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x[i][c] -= f.L[i][k] * x[k][c]
+			}
+			x[i][c] /= f.L[i][i]
+		}
+
+		// L^T * X = Y
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x[i][c] -= f.L[k][i] * x[k][c]
+			}
+			x[i][c] /= f.L[i][i]
+		}
+	}
+
+	return x, nil
+}
@@ -0,0 +1,93 @@
+package pok2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixCholesky(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedResult pok2.Matrix
+		expectedDet    float64
+		expectedError  error
+	}{
+		"basic cholesky decomposition": {
+			matrix: pok2.Matrix{
+				{4, 12, -16},
+				{12, 37, -43},
+				{-16, -43, 98},
+			},
+			expectedResult: pok2.Matrix{
+				{2, 0, 0},
+				{6, 1, 0},
+				{-8, 5, 3},
+			},
+			expectedDet:   36,
+			expectedError: nil,
+		},
+		"cholesky decomposition of non-square matrix": {
+			matrix: pok2.Matrix{
+				{4, 12},
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Nie można rozłożyć macierzy niekwadratowej"),
+		},
+		"cholesky decomposition of non-symmetric matrix": {
+			matrix: pok2.Matrix{
+				{4, 7},
+				{2, 6},
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Macierz nie jest symetryczna"),
+		},
+		"cholesky decomposition of indefinite matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{2, 1},
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Macierz nie jest dodatnio określona"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			chol, err := c.matrix.Cholesky()
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, true, chol.L.IsSimilar(c.expectedResult, 1e-10))
+			assert.InDelta(t, c.expectedDet, chol.Det(), 1e-8)
+		})
+	}
+}
+
+func TestCholeskySolve(t *testing.T) {
+	m := pok2.Matrix{
+		{4, 12, -16},
+		{12, 37, -43},
+		{-16, -43, 98},
+	}
+	rhs := pok2.Matrix{
+		{0, 4},
+		{6, 12},
+		{39, -16},
+	}
+	expected := pok2.Matrix{
+		{1, 1},
+		{1, 0},
+		{1, 0},
+	}
+
+	chol, err := m.Cholesky()
+	assert.Nil(t, err)
+
+	solved, err := chol.Solve(rhs)
+	assert.Nil(t, err)
+	assert.Equal(t, true, solved.IsSimilar(expected, 1e-10))
+}
@@ -19,7 +19,7 @@ func SortCoordinatePairs(cp []CoordinatePair) {
 
 // SlicesToCoordinatePairs to funkcja, która otrzymuje dwa wycinki liczb zmiennoprzecinkowych (x i y), zamienia je w wycinek CoordinatePairs i zwraca wynik.
 func SlicesToCoordinatePairs(x, y []float64) []CoordinatePair {
-	cp := make([]CoordinatePair, len(x))
+	cp := make([]CoordinatePair, 0, len(x))
 	for i := 0; i < len(x); i++ {
 		cp = append(cp, CoordinatePair{X: x[i], Y: y[i]})
 	}
@@ -0,0 +1,312 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Dense to gęsta macierz przechowywana wierszami w jednym ciągłym wycinku float64.
+// Element (i, j) znajduje się pod indeksem i*stride + j, dzięki czemu podmacierze mogą współdzielić pamięć z macierzą nadrzędną.
+type Dense struct {
+	data   []float64
+	rows   int
+	cols   int
+	stride int
+}
+
+// NewDense otrzymuje liczbę wierszy, kolumn i dane zapisane wierszami.
+// Jeśli data jest nil, alokowana jest macierz zerowa. Zwraca nową macierz gęstą i błąd (jeśli istnieje).
+func NewDense(rows, cols int, data []float64) (*Dense, error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("Wymiary macierzy nie mogą być ujemne")
+	}
+
+	if data == nil {
+		data = make([]float64, rows*cols)
+	} else if len(data) != rows*cols {
+		return nil, fmt.Errorf("Liczba elementów musi być równa iloczynowi liczby wierszy i kolumn")
+	}
+
+	return &Dense{data: data, rows: rows, cols: cols, stride: cols}, nil
+}
+
+// ToDense zwraca kopię macierzy w postaci gęstej oraz błąd, jeśli wiersze mają różne długości.
+func (m Matrix) ToDense() (*Dense, error) {
+	rows, cols := m.Dim()
+	d, err := NewDense(rows, cols, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range m {
+		if len(m[i]) != cols {
+			return nil, &RaggedError{Row: i, Len: len(m[i]), Cols: cols}
+		}
+		copy(d.data[i*d.stride:i*d.stride+cols], m[i])
+	}
+
+	return d, nil
+}
+
+// ToMatrix zwraca kopię macierzy gęstej w postaci Matrix.
+func (d *Dense) ToMatrix() Matrix {
+	m := make(Matrix, d.rows)
+	for i := range m {
+		m[i] = make(Vector, d.cols)
+		copy(m[i], d.rawRow(i))
+	}
+	return m
+}
+
+// Dim zwraca wymiary macierzy w postaci (wiersze, kolumny).
+func (d *Dense) Dim() (int, int) {
+	return d.rows, d.cols
+}
+
+// At zwraca element macierzy w wierszu i i kolumnie j. Indeksy spoza zakresu powodują panikę, tak jak przy indeksowaniu wycinka.
+func (d *Dense) At(i, j int) float64 {
+	d.checkIndex(i, j)
+	return d.data[i*d.stride+j]
+}
+
+// Set ustawia element macierzy w wierszu i i kolumnie j na podaną wartość.
+func (d *Dense) Set(i, j int, val float64) {
+	d.checkIndex(i, j)
+	d.data[i*d.stride+j] = val
+}
+
+func (d *Dense) checkIndex(i, j int) {
+	if i < 0 || i >= d.rows || j < 0 || j >= d.cols {
+		panic(fmt.Sprintf("pok2: indeks (%d, %d) poza zakresem macierzy %dx%d", i, j, d.rows, d.cols))
+	}
+}
+
+// rawRow zwraca wiersz i jako wycinek współdzielący pamięć z macierzą.
+func (d *Dense) rawRow(i int) []float64 {
+	return d.data[i*d.stride : i*d.stride+d.cols]
+}
+
+// Row otrzymuje indeks jako parametr.
+// Zwraca kopię wiersza pod podanym indeksem i błąd (jeśli istnieje).
+func (d *Dense) Row(i int) (Vector, error) {
+	if i < 0 {
+		return nil, fmt.Errorf("Indeks nie może być ujemny")
+	} else if i >= d.rows {
+		return nil, fmt.Errorf("Indeks nie może być większy niż długość")
+	}
+	r := make(Vector, d.cols)
+	copy(r, d.rawRow(i))
+	return r, nil
+}
+
+// Col otrzymuje indeks jako parametr.
+// Zwraca kopię kolumny pod podanym indeksem i błąd (jeśli istnieje).
+func (d *Dense) Col(j int) (Vector, error) {
+	if j < 0 {
+		return nil, fmt.Errorf("Indeks nie może być ujemny")
+	} else if j >= d.cols {
+		return nil, fmt.Errorf("Indeks nie może być większy niż długość")
+	}
+	c := make(Vector, d.rows)
+	for i := range c {
+		c[i] = d.data[i*d.stride+j]
+	}
+	return c, nil
+}
+
+// Slice zwraca podmacierz złożoną z wierszy [i0, i1) i kolumn [j0, j1) oraz błąd (jeśli istnieje).
+// Podmacierz współdzieli pamięć z macierzą, więc zmiany jednej są widoczne w drugiej.
+func (d *Dense) Slice(i0, i1, j0, j1 int) (*Dense, error) {
+	if i0 < 0 || j0 < 0 || i1 > d.rows || j1 > d.cols || i0 > i1 || j0 > j1 {
+		return nil, fmt.Errorf("Zakres podmacierzy wykracza poza wymiary macierzy")
+	}
+
+	rows, cols := i1-i0, j1-j0
+	if rows == 0 || cols == 0 {
+		return &Dense{rows: rows, cols: cols, stride: d.stride}, nil
+	}
+
+	start := i0*d.stride + j0
+	end := (i1-1)*d.stride + j1
+	return &Dense{data: d.data[start:end], rows: rows, cols: cols, stride: d.stride}, nil
+}
+
+// Clone zwraca głęboką kopię macierzy o ciągłym układzie w pamięci.
+func (d *Dense) Clone() *Dense {
+	c, _ := NewDense(d.rows, d.cols, nil)
+	for i := 0; i < d.rows; i++ {
+		copy(c.rawRow(i), d.rawRow(i))
+	}
+	return c
+}
+
+// Transpose zwraca transponowaną macierz.
+func (d *Dense) Transpose() *Dense {
+	t, _ := NewDense(d.cols, d.rows, nil)
+	for i := 0; i < d.rows; i++ {
+		row := d.rawRow(i)
+		for j, val := range row {
+			t.data[j*t.stride+i] = val
+		}
+	}
+	return t
+}
+
+// Add otrzymuje inną macierz gęstą jako parametr.
+// Dodaje dwie macierze i zwraca macierz wyników oraz błąd (jeśli taki istnieje).
+func (d *Dense) Add(d2 *Dense) (*Dense, error) {
+	if d.rows != d2.rows || d.cols != d2.cols {
+		return nil, fmt.Errorf("Wymiary macierzy muszą być zgodne")
+	}
+
+	r, _ := NewDense(d.rows, d.cols, nil)
+	for i := 0; i < d.rows; i++ {
+		a, b, out := d.rawRow(i), d2.rawRow(i), r.rawRow(i)
+		for j := range out {
+			out[j] = a[j] + b[j]
+		}
+	}
+	return r, nil
+}
+
+// Subtract otrzymuje inną macierz gęstą jako parametr.
+// Odejmuje dwie macierze i zwraca macierz wyników oraz błąd (jeśli istnieje).
+func (d *Dense) Subtract(d2 *Dense) (*Dense, error) {
+	if d.rows != d2.rows || d.cols != d2.cols {
+		return nil, fmt.Errorf("Wymiary macierzy muszą być zgodne")
+	}
+
+	r, _ := NewDense(d.rows, d.cols, nil)
+	for i := 0; i < d.rows; i++ {
+		a, b, out := d.rawRow(i), d2.rawRow(i), r.rawRow(i)
+		for j := range out {
+			out[j] = a[j] - b[j]
+		}
+	}
+	return r, nil
+}
+
+// MultiplyByScalar otrzymuje skalar jako parametr. Mnoży wszystkie elementy macierzy przez skalar i zwraca wynikową macierz.
+func (d *Dense) MultiplyByScalar(s float64) *Dense {
+	r, _ := NewDense(d.rows, d.cols, nil)
+	for i := 0; i < d.rows; i++ {
+		a, out := d.rawRow(i), r.rawRow(i)
+		for j := range out {
+			out[j] = a[j] * s
+		}
+	}
+	return r
+}
+
+// multiplyBlockSize to rozmiar kafelka (w elementach) używany przy blokowym mnożeniu macierzy.
+const multiplyBlockSize = 64
+
+// parallelMultiplyThreshold to liczba mnożeń skalarnych, poniżej której iloczyn liczony jest sekwencyjnie,
+// bo koszt uruchomienia gorutyn przewyższa zysk z równoległości.
+const parallelMultiplyThreshold = 128 * 128 * 128
+
+// MultiplyBy otrzymuje inną macierz gęstą jako parametr.
+// Mnoży macierze i zwraca wynikową macierz i błąd.
+// Duże iloczyny liczone są blokowo i równolegle na runtime.GOMAXPROCS(0) gorutynach, zob. MultiplyByWorkers.
+func (d *Dense) MultiplyBy(d2 *Dense) (*Dense, error) {
+	return d.MultiplyByWorkers(d2, 0)
+}
+
+// MultiplyByWorkers otrzymuje inną macierz gęstą i liczbę gorutyn jako parametry.
+// Mnoży macierze, dzieląc wiersze wyniku na bloki przetwarzane równolegle, a w obrębie bloku dzieląc obliczenia na kafelki mieszczące się w pamięci podręcznej.
+// Dla workers <= 0 używana jest wartość runtime.GOMAXPROCS(0). Małe iloczyny oraz workers == 1 liczone są sekwencyjnie.
+// Zwraca wynikową macierz i błąd.
+func (d *Dense) MultiplyByWorkers(d2 *Dense, workers int) (*Dense, error) {
+	if d.cols != d2.rows {
+		return nil, fmt.Errorf("Liczba kolumn pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy")
+	}
+
+	r, _ := NewDense(d.rows, d2.cols, nil)
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > d.rows {
+		workers = d.rows
+	}
+
+	if workers <= 1 || d.rows*d.cols*d2.cols < parallelMultiplyThreshold {
+		mulRows(r, d, d2, 0, d.rows)
+		return r, nil
+	}
+
+	chunk := (d.rows + workers - 1) / workers
+	var wg sync.WaitGroup
+	for from := 0; from < d.rows; from += chunk {
+		to := from + chunk
+		if to > d.rows {
+			to = d.rows
+		}
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			mulBlocked(r, d, d2, from, to)
+		}(from, to)
+	}
+	wg.Wait()
+
+	return r, nil
+}
+
+// mulRows dodaje do wierszy [from, to) macierzy r iloczyn odpowiadających im wierszy a i macierzy b.
+func mulRows(r, a, b *Dense, from, to int) {
+	for i := from; i < to; i++ {
+		out := r.rawRow(i)
+		for k, aik := range a.rawRow(i) {
+			for j, bkj := range b.rawRow(k) {
+				out[j] += aik * bkj
+			}
+		}
+	}
+}
+
+// mulBlocked działa jak mulRows, ale przechodzi po kafelkach kolumn a i b, aby fragment b pozostawał w pamięci podręcznej.
+// Dla każdego elementu wyniku składniki sumowane są w tej samej kolejności co w mulRows.
+func mulBlocked(r, a, b *Dense, from, to int) {
+	for kk := 0; kk < a.cols; kk += multiplyBlockSize {
+		kEnd := kk + multiplyBlockSize
+		if kEnd > a.cols {
+			kEnd = a.cols
+		}
+		for jj := 0; jj < b.cols; jj += multiplyBlockSize {
+			jEnd := jj + multiplyBlockSize
+			if jEnd > b.cols {
+				jEnd = b.cols
+			}
+			for i := from; i < to; i++ {
+				out := r.rawRow(i)[jj:jEnd]
+				aRow := a.rawRow(i)
+				for k := kk; k < kEnd; k++ {
+					aik := aRow[k]
+					for j, bkj := range b.rawRow(k)[jj:jEnd] {
+						out[j] += aik * bkj
+					}
+				}
+			}
+		}
+	}
+}
+
+// IsSimilar otrzymuje inną macierz gęstą i tolerancję jako parametry.
+// Sprawdza, czy dwie macierze są podobne w ramach podanej tolerancji.
+func (d *Dense) IsSimilar(d2 *Dense, tol float64) bool {
+	if d.rows != d2.rows || d.cols != d2.cols {
+		return false
+	}
+	for i := 0; i < d.rows; i++ {
+		a, b := d.rawRow(i), d2.rawRow(i)
+		for j := range a {
+			if math.Abs(a[j]-b[j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package pok2_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+var benchmarkSizes = []int{64, 256, 512}
+
+func randomMatrix(rows, cols int, seed int64) pok2.Matrix {
+	r := rand.New(rand.NewSource(seed))
+	m := make(pok2.Matrix, rows)
+	for i := range m {
+		m[i] = make(pok2.Vector, cols)
+		for j := range m[i] {
+			m[i][j] = r.Float64()
+		}
+	}
+	return m
+}
+
+// columnMultiply to pierwotny algorytm Matrix.MultiplyBy, który alokuje kolumnę dla każdego elementu wyniku
+func columnMultiply(m, m2 pok2.Matrix) pok2.Matrix {
+	var r pok2.Matrix
+	for i := range m {
+		r = append(r, pok2.Vector{})
+		for j := range m2[0] {
+			col, _ := m2.Col(j)
+			dot, _ := m[i].Dot(col)
+			r[i] = append(r[i], dot)
+		}
+	}
+	return r
+}
+
+func TestDenseParallelMultiplicationMatchesSerial(t *testing.T) {
+	cases := map[string]struct {
+		rows, inner, cols int
+		workers           int
+	}{
+		"square product":          {rows: 200, inner: 200, cols: 200, workers: 4},
+		"uneven row blocks":       {rows: 197, inner: 131, cols: 150, workers: 3},
+		"more workers than rows":  {rows: 3, inner: 700, cols: 1000, workers: 16},
+		"default number of tasks": {rows: 150, inner: 150, cols: 150, workers: 0},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m1 := randomMatrix(c.rows, c.inner, 1)
+			m2 := randomMatrix(c.inner, c.cols, 2)
+			d1, _ := m1.ToDense()
+			d2, _ := m2.ToDense()
+
+			serial, err := d1.MultiplyByWorkers(d2, 1)
+			assert.Nil(t, err)
+			parallel, err := d1.MultiplyByWorkers(d2, c.workers)
+			assert.Nil(t, err)
+
+			assert.Equal(t, serial.ToMatrix(), parallel.ToMatrix())
+			assert.Equal(t, true, parallel.ToMatrix().IsSimilar(columnMultiply(m1, m2), 1e-9))
+
+			viaMatrix, err := m1.MultiplyBy(m2)
+			assert.Nil(t, err)
+			assert.Equal(t, serial.ToMatrix(), viaMatrix)
+		})
+	}
+}
+
+func BenchmarkMatrixMultiplyByColumns(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		m1, m2 := randomMatrix(n, n, 1), randomMatrix(n, n, 2)
+		b.Run(fmt.Sprintf("%dx%d", n, n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				columnMultiply(m1, m2)
+			}
+		})
+	}
+}
+
+func BenchmarkMatrixMultiplyBy(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		m1, m2 := randomMatrix(n, n, 1), randomMatrix(n, n, 2)
+		b.Run(fmt.Sprintf("%dx%d", n, n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m1.MultiplyBy(m2)
+			}
+		})
+	}
+}
+
+func BenchmarkDenseMultiplySerial(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		d1, _ := randomMatrix(n, n, 1).ToDense()
+		d2, _ := randomMatrix(n, n, 2).ToDense()
+		b.Run(fmt.Sprintf("%dx%d", n, n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d1.MultiplyByWorkers(d2, 1)
+			}
+		})
+	}
+}
+
+func BenchmarkDenseMultiplyParallel(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		d1, _ := randomMatrix(n, n, 1).ToDense()
+		d2, _ := randomMatrix(n, n, 2).ToDense()
+		b.Run(fmt.Sprintf("%dx%d", n, n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d1.MultiplyByWorkers(d2, 0)
+			}
+		})
+	}
+}
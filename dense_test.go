@@ -0,0 +1,185 @@
+package pok2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDense(t *testing.T) {
+	cases := map[string]struct {
+		rows          int
+		cols          int
+		data          []float64
+		expectedError error
+	}{
+		"dense matrix from data": {
+			rows:          2,
+			cols:          3,
+			data:          []float64{1, 2, 3, 4, 5, 6},
+			expectedError: nil,
+		},
+		"zero dense matrix": {
+			rows:          2,
+			cols:          2,
+			data:          nil,
+			expectedError: nil,
+		},
+		"dense matrix with wrong data length": {
+			rows:          2,
+			cols:          2,
+			data:          []float64{1, 2, 3},
+			expectedError: fmt.Errorf("Liczba elementów musi być równa iloczynowi liczby wierszy i kolumn"),
+		},
+		"dense matrix with negative dimensions": {
+			rows:          -1,
+			cols:          2,
+			expectedError: fmt.Errorf("Wymiary macierzy nie mogą być ujemne"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := pok2.NewDense(c.rows, c.cols, c.data)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+			rows, cols := d.Dim()
+			assert.Equal(t, c.rows, rows)
+			assert.Equal(t, c.cols, cols)
+		})
+	}
+}
+
+func TestDenseConversions(t *testing.T) {
+	cases := map[string]struct {
+		matrix        pok2.Matrix
+		expectedError error
+	}{
+		"basic conversion": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5, 6},
+			},
+			expectedError: nil,
+		},
+		"ragged matrix conversion": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5},
+			},
+			expectedError: &pok2.RaggedError{Row: 1, Len: 2, Cols: 3},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := c.matrix.ToDense()
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, c.matrix, d.ToMatrix())
+			assert.Equal(t, c.matrix[1][2], d.At(1, 2))
+		})
+	}
+}
+
+func TestDenseMultiplication(t *testing.T) {
+	cases := map[string]struct {
+		matrix1        pok2.Matrix
+		matrix2        pok2.Matrix
+		expectedResult pok2.Matrix
+		expectedError  error
+	}{
+		"basic dense multiplication": {
+			matrix1: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5, 6},
+			},
+			matrix2: pok2.Matrix{
+				{1, 1},
+				{2, 3},
+				{5, 2},
+			},
+			expectedResult: pok2.Matrix{
+				{20, 13},
+				{44, 31},
+			},
+			expectedError: nil,
+		},
+		"dense multiplication with wrong dimensions": {
+			matrix1: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5, 6},
+			},
+			matrix2: pok2.Matrix{
+				{1, 4},
+				{2, 5},
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Liczba kolumn pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d1, _ := c.matrix1.ToDense()
+			d2, _ := c.matrix2.ToDense()
+			product, err := d1.MultiplyBy(d2)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, c.expectedResult, product.ToMatrix())
+		})
+	}
+}
+
+func TestDenseArithmetic(t *testing.T) {
+	d1, _ := pok2.Matrix{{1, 2}, {3, 4}}.ToDense()
+	d2, _ := pok2.Matrix{{4, 3}, {2, 1}}.ToDense()
+
+	sum, err := d1.Add(d2)
+	assert.Nil(t, err)
+	assert.Equal(t, pok2.Matrix{{5, 5}, {5, 5}}, sum.ToMatrix())
+
+	diff, err := d1.Subtract(d2)
+	assert.Nil(t, err)
+	assert.Equal(t, pok2.Matrix{{-3, -1}, {1, 3}}, diff.ToMatrix())
+
+	assert.Equal(t, pok2.Matrix{{2, 4}, {6, 8}}, d1.MultiplyByScalar(2).ToMatrix())
+	assert.Equal(t, pok2.Matrix{{1, 3}, {2, 4}}, d1.Transpose().ToMatrix())
+
+	d3, _ := pok2.NewDense(1, 2, nil)
+	_, err = d1.Add(d3)
+	assert.Equal(t, fmt.Errorf("Wymiary macierzy muszą być zgodne"), err)
+}
+
+func TestDenseSlice(t *testing.T) {
+	d, _ := pok2.Matrix{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}.ToDense()
+
+	sub, err := d.Slice(1, 3, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, pok2.Matrix{{5, 6}, {8, 9}}, sub.ToMatrix())
+
+	col, _ := sub.Col(0)
+	assert.Equal(t, pok2.Vector{5, 8}, col)
+
+	// Widok współdzieli pamięć z macierzą nadrzędną
+	sub.Set(0, 0, 50)
+	assert.Equal(t, 50.0, d.At(1, 1))
+
+	product, _ := sub.MultiplyBy(sub.Clone())
+	assert.Equal(t, pok2.Matrix{{50*50 + 6*8, 50*6 + 6*9}, {8*50 + 9*8, 8*6 + 9*9}}, product.ToMatrix())
+
+	_, err = d.Slice(0, 4, 0, 1)
+	assert.Equal(t, fmt.Errorf("Zakres podmacierzy wykracza poza wymiary macierzy"), err)
+}
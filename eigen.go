@@ -0,0 +1,326 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// maxQRIterations ogranicza liczbę iteracji przesuniętej metody QR przypadającą na jedną wartość własną.
+const maxQRIterations = 30
+
+// EigenSym zwraca wartości własne symetrycznej macierzy uporządkowane rosnąco oraz macierz,
+// której kolumny są odpowiadającymi im ortonormalnymi wektorami własnymi, i błąd (jeśli istnieje).
+// Wartości własne wyznaczane są cykliczną metodą Jacobiego; jeśli nie zbiegnie ona w maxJacobiSweeps przebiegach, zwracany jest błąd.
+func (m Matrix) EigenSym() (Vector, Matrix, error) {
+	if err := m.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if !m.isSquare() {
+		return nil, nil, fmt.Errorf("Nie można wyznaczyć wartości własnych macierzy niekwadratowej")
+	}
+
+	n, _ := m.Dim()
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			scale := math.Max(1, math.Max(math.Abs(m[i][j]), math.Abs(m[j][i])))
+			if math.Abs(m[i][j]-m[j][i]) > 1e-10*scale {
+				return nil, nil, fmt.Errorf("Macierz nie jest symetryczna")
+			}
+		}
+	}
+
+	a := m.copy()
+	v := identity(n)
+
+	converged := false
+	for sweep := 0; sweep <= maxJacobiSweeps; sweep++ {
+		var off, diag float64
+		for i := 0; i < n; i++ {
+			diag += a[i][i] * a[i][i]
+			for j := i + 1; j < n; j++ {
+				off += a[i][j] * a[i][j]
+			}
+		}
+		if off <= eps*eps*diag || off == 0 {
+			converged = true
+			break
+		}
+		if sweep == maxJacobiSweeps {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				// A = J^T * A * J, gdzie J jest obrotem w płaszczyźnie (p, q)
+				rotateColumns(a, p, q, c, s)
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				rotateColumns(v, p, q, c, s)
+			}
+		}
+	}
+	if !converged {
+		return nil, nil, fmt.Errorf("Metoda Jacobiego nie zbiegła po %d przebiegach", maxJacobiSweeps)
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return a[order[i]][order[i]] < a[order[j]][order[j]]
+	})
+
+	values := make(Vector, n)
+	vectors := make(Matrix, n)
+	for i := range vectors {
+		vectors[i] = make(Vector, n)
+	}
+	for j, o := range order {
+		values[j] = a[o][o]
+		for i := 0; i < n; i++ {
+			vectors[i][j] = v[i][o]
+		}
+	}
+
+	return values, vectors, nil
+}
+
+// Eigen zwraca wszystkie (w ogólności zespolone) wartości własne macierzy kwadratowej oraz błąd (jeśli istnieje).
+// Macierz jest sprowadzana do postaci Hessenberga odbiciami Householdera, a następnie stosowana jest
+// metoda QR z podwójnym przesunięciem Francisa. Wartości własne są uporządkowane rosnąco według
+// części rzeczywistej, a następnie urojonej.
+func (m Matrix) Eigen() ([]complex128, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !m.isSquare() {
+		return nil, fmt.Errorf("Nie można wyznaczyć wartości własnych macierzy niekwadratowej")
+	}
+
+	h := m.copy()
+	reduceToHessenberg(h)
+
+	values, err := hessenbergEigenvalues(h)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		if real(values[i]) != real(values[j]) {
+			return real(values[i]) < real(values[j])
+		}
+		return imag(values[i]) < imag(values[j])
+	})
+
+	return values, nil
+}
+
+// reduceToHessenberg sprowadza macierz h w miejscu do górnej postaci Hessenberga przekształceniami
+// podobieństwa H = P * H * P, gdzie P jest odbiciem Householdera zerującym kolumnę k poniżej poddiagonali.
+func reduceToHessenberg(h Matrix) {
+	n := len(h)
+
+	for k := 0; k < n-2; k++ {
+		v := make(Vector, n-k-1)
+		var norm float64
+		for i := range v {
+			v[i] = h[k+1+i][k]
+			norm = math.Hypot(norm, v[i])
+		}
+		if norm == 0 {
+			continue
+		}
+
+		// Znak wybieramy tak, aby uniknąć odejmowania bliskich liczb
+		alpha := -math.Copysign(norm, v[0])
+		v[0] -= alpha
+		vv, _ := v.Dot(v)
+		if vv == 0 {
+			continue
+		}
+
+		applyReflector(h, v, vv, k+1, k)
+		applyReflectorRight(h, v, vv, 0, len(h), k+1)
+
+		h[k+1][k] = alpha
+		for i := k + 2; i < n; i++ {
+			h[i][k] = 0
+		}
+	}
+}
+
+// applyReflectorRight mnoży od prawej wiersze r0..r1-1 podmacierzy a[:, c:c+len(v)] przez odbicie I - 2*v*v^T/(v^T*v).
+func applyReflectorRight(a Matrix, v Vector, vv float64, r0, r1, c int) {
+	for i := r0; i < r1; i++ {
+		var s float64
+		for j := range v {
+			s += a[i][c+j] * v[j]
+		}
+		s = 2 * s / vv
+		for j := range v {
+			a[i][c+j] -= s * v[j]
+		}
+	}
+}
+
+// hessenbergEigenvalues wyznacza wartości własne macierzy Hessenberga h. Zawartość h zostaje zniszczona.
+// Aktywne okno h[lo:hi+1][lo:hi+1] jest iterowane krokami Francisa, dopóki element poddiagonalny
+// nie stanie się pomijalnie mały i nie odetnie od okna bloku 1x1 lub 2x2.
+func hessenbergEigenvalues(h Matrix) ([]complex128, error) {
+	n := len(h)
+	values := make([]complex128, 0, n)
+
+	var norm float64
+	for i := range h {
+		for j := range h[i] {
+			norm += math.Abs(h[i][j])
+		}
+	}
+
+	hi := n - 1
+	its := 0
+	for hi >= 0 {
+		// Szukamy od dołu pomijalnie małego elementu poddiagonalnego, który wyznacza początek okna
+		lo := hi
+		for lo > 0 {
+			s := math.Abs(h[lo-1][lo-1]) + math.Abs(h[lo][lo])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(h[lo][lo-1]) <= eps*s {
+				h[lo][lo-1] = 0
+				break
+			}
+			lo--
+		}
+
+		switch {
+		case lo == hi:
+			values = append(values, complex(h[hi][hi], 0))
+			hi--
+			its = 0
+		case lo == hi-1:
+			values = append(values, eigenvalues2x2(h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi])...)
+			hi -= 2
+			its = 0
+		default:
+			if its == maxQRIterations {
+				return nil, fmt.Errorf("Metoda QR nie zbiegła")
+			}
+			its++
+
+			// Przesunięcia są wartościami własnymi dolnego bloku 2x2: s to ich suma, a t iloczyn
+			a, b, c, d := h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi]
+			s, t := a+d, a*d-b*c
+
+			// Co dziesiątą iterację bez deflacji używamy podwójnego rzeczywistego przesunięcia
+			// przesuniętego o wielkość elementów poddiagonalnych, aby wyrwać się z cyklu
+			if its%10 == 0 {
+				mu := d + math.Abs(c) + math.Abs(h[hi-1][hi-2])
+				s, t = 2*mu, mu*mu
+			}
+
+			francisStep(h, lo, hi, s, t)
+		}
+	}
+
+	return values, nil
+}
+
+// eigenvalues2x2 zwraca wartości własne macierzy [[a, b], [c, d]].
+func eigenvalues2x2(a, b, c, d float64) []complex128 {
+	p := (a - d) / 2
+	q := p*p + b*c
+
+	if q < 0 {
+		im := math.Sqrt(-q)
+		return []complex128{complex(d+p, im), complex(d+p, -im)}
+	}
+
+	// Druga wartość z iloczynu pierwiastków, aby uniknąć utraty cyfr znaczących
+	z := p + math.Copysign(math.Sqrt(q), p)
+	if z == 0 {
+		return []complex128{complex(d, 0), complex(d, 0)}
+	}
+	return []complex128{complex(d+z, 0), complex(d-b*c/z, 0)}
+}
+
+// francisStep wykonuje jeden krok QR z niejawnym podwójnym przesunięciem na oknie h[lo:hi+1][lo:hi+1],
+// gdzie s i t są sumą i iloczynem przesunięć. Wybrzuszenie tworzone przez pierwszą kolumnę
+// (H - s1*I)(H - s2*I) jest przesuwane wzdłuż poddiagonali odbiciami Householdera rozmiaru 3.
+func francisStep(h Matrix, lo, hi int, s, t float64) {
+	x := h[lo][lo]*h[lo][lo] + h[lo][lo+1]*h[lo+1][lo] - s*h[lo][lo] + t
+	y := h[lo+1][lo] * (h[lo][lo] + h[lo+1][lo+1] - s)
+	z := h[lo+1][lo] * h[lo+2][lo+1]
+
+	for k := lo; k <= hi-1; k++ {
+		v := Vector{x, y}
+		if k < hi-1 {
+			v = append(v, z)
+		}
+
+		var norm float64
+		for _, e := range v {
+			norm = math.Hypot(norm, e)
+		}
+		if norm != 0 {
+			v[0] += math.Copysign(norm, v[0])
+			vv, _ := v.Dot(v)
+
+			// Odbicie działa na wiersze i kolumny k..k+len(v)-1 okna
+			col := lo
+			if k > lo {
+				col = k - 1
+			}
+			applyReflectorWindow(h, v, vv, k, col, hi+1)
+
+			last := k + len(v)
+			if last > hi {
+				last = hi
+			}
+			applyReflectorRight(h, v, vv, lo, last+1, k)
+		}
+
+		if k < hi-1 {
+			x = h[k+1][k]
+			y = h[k+2][k]
+			if k < hi-2 {
+				z = h[k+3][k]
+			}
+		}
+	}
+}
+
+// applyReflectorWindow mnoży od lewej podmacierz a[r:r+len(v), c:c1] przez odbicie I - 2*v*v^T/(v^T*v).
+func applyReflectorWindow(a Matrix, v Vector, vv float64, r, c, c1 int) {
+	for j := c; j < c1; j++ {
+		var s float64
+		for i := range v {
+			s += v[i] * a[r+i][j]
+		}
+		s = 2 * s / vv
+		for i := range v {
+			a[r+i][j] -= s * v[i]
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package pok2_test
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixEigenSym(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedValues pok2.Vector
+		expectedError  error
+	}{
+		"2x2 symmetric matrix": {
+			matrix: pok2.Matrix{
+				{2, 1},
+				{1, 2},
+			},
+			expectedValues: pok2.Vector{1, 3},
+			expectedError:  nil,
+		},
+		"3x3 symmetric matrix": {
+			matrix: pok2.Matrix{
+				{2, -1, 0},
+				{-1, 2, -1},
+				{0, -1, 2},
+			},
+			expectedValues: pok2.Vector{2 - math.Sqrt2, 2, 2 + math.Sqrt2},
+			expectedError:  nil,
+		},
+		"diagonal matrix": {
+			matrix: pok2.Matrix{
+				{5, 0},
+				{0, -1},
+			},
+			expectedValues: pok2.Vector{-1, 5},
+			expectedError:  nil,
+		},
+		"non-symmetric matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{3, 4},
+			},
+			expectedError: fmt.Errorf("Macierz nie jest symetryczna"),
+		},
+		"matrix with NaN does not converge": {
+			matrix: pok2.Matrix{
+				{1, math.NaN()},
+				{math.NaN(), 1},
+			},
+			expectedError: fmt.Errorf("Metoda Jacobiego nie zbiegła po 100 przebiegach"),
+		},
+		"non-square matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+			},
+			expectedError: fmt.Errorf("Nie można wyznaczyć wartości własnych macierzy niekwadratowej"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			values, vectors, err := c.matrix.EigenSym()
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, true, values.IsSimilar(c.expectedValues, 1e-10))
+
+			// A * V = V * diag(values)
+			av, _ := c.matrix.MultiplyBy(vectors)
+			for i := range av {
+				for j := range av[i] {
+					assert.InDelta(t, vectors[i][j]*values[j], av[i][j], 1e-10)
+				}
+			}
+
+			// V^T * V = I
+			vT, _ := vectors.Transpose()
+			vtv, _ := vT.MultiplyBy(vectors)
+			for i := range vtv {
+				for j := range vtv[i] {
+					expected := 0.0
+					if i == j {
+						expected = 1
+					}
+					assert.InDelta(t, expected, vtv[i][j], 1e-10)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrixEigen(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedValues []complex128
+		expectedError  error
+	}{
+		"real eigenvalues": {
+			matrix: pok2.Matrix{
+				{4, 1},
+				{2, 3},
+			},
+			expectedValues: []complex128{2, 5},
+			expectedError:  nil,
+		},
+		"rotation matrix": {
+			matrix: pok2.Matrix{
+				{0, -1},
+				{1, 0},
+			},
+			expectedValues: []complex128{-1i, 1i},
+			expectedError:  nil,
+		},
+		"companion matrix of (x-1)(x-2)(x-3)(x-4)": {
+			matrix: pok2.Matrix{
+				{10, -35, 50, -24},
+				{1, 0, 0, 0},
+				{0, 1, 0, 0},
+				{0, 0, 1, 0},
+			},
+			expectedValues: []complex128{1, 2, 3, 4},
+			expectedError:  nil,
+		},
+		"mixed real and complex eigenvalues": {
+			matrix: pok2.Matrix{
+				{1, 2, 0},
+				{-2, 1, 0},
+				{0, 0, 3},
+			},
+			expectedValues: []complex128{1 - 2i, 1 + 2i, 3},
+			expectedError:  nil,
+		},
+		"cyclic permutation 3x3": {
+			matrix: pok2.Matrix{
+				{0, 0, 1},
+				{1, 0, 0},
+				{0, 1, 0},
+			},
+			expectedValues: []complex128{complex(-0.5, -math.Sqrt(3)/2), complex(-0.5, math.Sqrt(3)/2), 1},
+			expectedError:  nil,
+		},
+		"cyclic permutation 4x4": {
+			matrix: pok2.Matrix{
+				{0, 0, 0, 1},
+				{1, 0, 0, 0},
+				{0, 1, 0, 0},
+				{0, 0, 1, 0},
+			},
+			expectedValues: []complex128{-1, -1i, 1i, 1},
+			expectedError:  nil,
+		},
+		"block triangular matrix": {
+			matrix: pok2.Matrix{
+				{2, 5, 1, 7, 3},
+				{0, 3, 4, 1, 2},
+				{0, 0, 1, -1, 6},
+				{0, 0, 1, 1, 5},
+				{0, 0, 0, 0, -2},
+			},
+			expectedValues: []complex128{-2, 1 - 1i, 1 + 1i, 2, 3},
+			expectedError:  nil,
+		},
+		"non-square matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+			},
+			expectedError: fmt.Errorf("Nie można wyznaczyć wartości własnych macierzy niekwadratowej"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			values, err := c.matrix.Eigen()
+			assert.Equal(t, c.expectedError, err)
+			assert.Equal(t, len(c.expectedValues), len(values))
+			for i := range values {
+				assert.InDelta(t, 0, cmplx.Abs(values[i]-c.expectedValues[i]), 1e-8)
+			}
+		})
+	}
+}
+
+func TestMatrixEigenInvariants(t *testing.T) {
+	cases := map[string]pok2.Matrix{
+		"symmetric 5x5": {
+			{4, 1, -2, 2, 0},
+			{1, 2, 0, 1, 3},
+			{-2, 0, 3, -2, 1},
+			{2, 1, -2, -1, 2},
+			{0, 3, 1, 2, 5},
+		},
+		"general 6x6": {
+			{3, -1, 4, 1, -5, 9},
+			{2, 6, -5, 3, 5, 8},
+			{-9, 7, 9, 3, 2, -3},
+			{8, 4, -6, 2, 6, 4},
+			{3, 3, 8, -3, 2, 7},
+			{9, -5, 0, 2, 8, 8},
+		},
+	}
+
+	for name, m := range cases {
+		t.Run(name, func(t *testing.T) {
+			values, err := m.Eigen()
+			assert.Nil(t, err)
+			assert.Equal(t, len(m), len(values))
+
+			// Suma wartości własnych to ślad, a iloczyn to wyznacznik
+			sum, prod := complex(0, 0), complex(1, 0)
+			for _, v := range values {
+				sum += v
+				prod *= v
+			}
+			trace, _ := m.Trace()
+			det, _ := m.Det()
+			assert.InDelta(t, 0, cmplx.Abs(sum-complex(trace, 0)), 1e-8)
+			assert.InDelta(t, 0, cmplx.Abs(prod-complex(det, 0))/math.Max(1, math.Abs(det)), 1e-8)
+
+			// Każda wartość własna jest pierwiastkiem wielomianu charakterystycznego: det(A - v*I) = 0
+			for _, v := range values {
+				if imag(v) != 0 {
+					continue
+				}
+				shifted := make(pok2.Matrix, len(m))
+				for i := range m {
+					shifted[i] = append(pok2.Vector{}, m[i]...)
+					shifted[i][i] -= real(v)
+				}
+				d, _ := shifted.Det()
+				assert.InDelta(t, 0, d/math.Max(1, math.Abs(det)), 1e-8)
+			}
+
+			sym, _, err := m.EigenSym()
+			if err == nil {
+				for i := range sym {
+					assert.InDelta(t, 0, cmplx.Abs(values[i]-complex(sym[i], 0)), 1e-8)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package akima
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/53jk1/pok2/interpolate"
+)
+
+// Akima zapewnia interpolację sześcienną Hermite'a z pochodnymi wyznaczanymi metodą Akimy.
+// Pochodna w węźle zależy tylko od sąsiednich nachyleń, więc pojedynczy odstający punkt nie wywołuje oscylacji na całym zakresie,
+// a odcinki współliniowe są odtwarzane dokładnie. W przeciwieństwie do PCHIP metoda nie gwarantuje jednak monotoniczności.
+type Akima struct {
+	interpolate.Base
+
+	// pp to interpolant zapisany jako wielomiany Hermite'a na kolejnych przedziałach XYPairs
+	pp *interpolate.Piecewise
+}
+
+func init() {
+	interpolate.Register("akima", func() interpolate.Interpolator { return New() })
+}
+
+// New zwraca nowy obiekt Akima.
+func New() *Akima {
+	a := &Akima{}
+	return a
+}
+
+// Fit otrzymuje wycinki współrzędnych x i y, sortuje punkty i wyznacza pochodne w węzłach.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, punktów jest mniej niż dwa lub wartości X się powtarzają.
+func (a *Akima) Fit(x, y []float64) error {
+	a.pp = nil
+
+	if err := a.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(a.XYPairs)
+	if n < 2 {
+		return fmt.Errorf("Do interpolacji Akimy potrzebne są co najmniej 2 punkty")
+	}
+
+	// m[i+2] to nachylenie odcinka i; po dwa dodatkowe nachylenia z każdej strony ekstrapolujemy liniowo
+	m := make([]float64, n+3)
+	for i := 0; i < n-1; i++ {
+		h := a.XYPairs[i+1].X - a.XYPairs[i].X
+		if h == 0 {
+			return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X")
+		}
+		m[i+2] = (a.XYPairs[i+1].Y - a.XYPairs[i].Y) / h
+	}
+
+	d := make([]float64, n)
+	if n == 2 {
+		d[0], d[1] = m[2], m[2]
+		a.pp = interpolate.NewHermite(a.XYPairs, d)
+		return nil
+	}
+
+	m[1] = 2*m[2] - m[3]
+	m[0] = 2*m[1] - m[2]
+	m[n+1] = 2*m[n] - m[n-1]
+	m[n+2] = 2*m[n+1] - m[n]
+
+	for i := 0; i < n; i++ {
+		w1 := math.Abs(m[i+3] - m[i+2])
+		w2 := math.Abs(m[i+1] - m[i])
+		if w1+w2 == 0 {
+			d[i] = (m[i+1] + m[i+2]) / 2
+			continue
+		}
+		d[i] = (w1*m[i+1] + w2*m[i+2]) / (w1 + w2)
+	}
+
+	a.pp = interpolate.NewHermite(a.XYPairs, d)
+	return nil
+}
+
+func (a *Akima) Interpolate(val float64) float64 {
+	if est, ok := a.Extrapolate(val, a.Interpolate); ok {
+		return est
+	}
+	return a.pp.Eval(val)
+}
+
+func (a *Akima) Validate(val float64) error {
+
+	if a.pp == nil {
+		return fmt.Errorf("Interpolant Akimy nie został dopasowany")
+	}
+
+	return a.ValidateRange(val)
+}
+
+// Derivative zwraca pochodną interpolanta rzędu order w punkcie x oraz błąd (jeśli istnieje).
+func (a *Akima) Derivative(x float64, order int) (float64, error) {
+	if err := a.Validate(x); err != nil {
+		return 0, err
+	}
+	return a.DerivativeOf(x, order, a.pp.Derivative)
+}
+
+// Integrate zwraca całkę oznaczoną interpolanta od from do to oraz błąd (jeśli istnieje).
+func (a *Akima) Integrate(from, to float64) (float64, error) {
+	if err := a.Validate(from); err != nil {
+		return 0, err
+	}
+	if err := a.Validate(to); err != nil {
+		return 0, err
+	}
+	return a.IntegralOf(from, to, a.pp.Integrate), nil
+}
@@ -0,0 +1,116 @@
+package akima_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/akima"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAkimaCanFit(t *testing.T) {
+	cases := map[string]struct {
+		x             []float64
+		y             []float64
+		expectedError error
+	}{
+		"basic akima fit": {
+			x:             []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 6.2},
+			y:             []float64{3.37, 4.45, 4.81, 3.96, 3.31, 2.72, 3.02, 3.43, 4.07},
+			expectedError: nil,
+		},
+		"wrong x and y size": {
+			x:             []float64{1.3, 1.8, 2.5},
+			y:             []float64{3.37, 4.45},
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"single point": {
+			x:             []float64{1.3},
+			y:             []float64{3.37},
+			expectedError: fmt.Errorf("Do interpolacji Akimy potrzebne są co najmniej 2 punkty"),
+		},
+		"same x values": {
+			x:             []float64{1.3, 1.8, 1.8},
+			y:             []float64{3.37, 4.45, 4.81},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same wartości X"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := akima.New()
+			err := a.Fit(c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestAkimaCanInterpolateSingleValue(t *testing.T) {
+	cases := map[string]struct {
+		x                  []float64
+		y                  []float64
+		valueToInterpolate float64
+		expectedEstimate   float64
+		expectedError      error
+	}{
+		"akima reproduces a line": {
+			x:                  []float64{0, 1, 3, 4, 6},
+			y:                  []float64{1, 3, 7, 9, 13},
+			valueToInterpolate: 2.2,
+			expectedEstimate:   5.4,
+			expectedError:      nil,
+		},
+		"akima between two points": {
+			x:                  []float64{0, 2},
+			y:                  []float64{1, 5},
+			valueToInterpolate: 0.5,
+			expectedEstimate:   2,
+			expectedError:      nil,
+		},
+		"akima keeps collinear segments straight next to a step": {
+			x:                  []float64{0, 1, 2, 3, 4, 5},
+			y:                  []float64{0, 0, 0, 1, 1, 1},
+			valueToInterpolate: 1.5,
+			expectedEstimate:   0,
+			expectedError:      nil,
+		},
+		"big value to interpolate test": {
+			x:                  []float64{1.8, 4.9, 2.5, 1.3},
+			y:                  []float64{4.45, 3.02, 4.81, 3.37},
+			valueToInterpolate: 1000,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+		"too small value to interpolate test": {
+			x:                  []float64{1.8, 4.9, 2.5, 1.3},
+			y:                  []float64{4.45, 3.02, 4.81, 3.37},
+			valueToInterpolate: -20,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := akima.New()
+			a.Fit(c.x, c.y)
+			estimate, err := interpolate.WithSingle(a, c.valueToInterpolate)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestAkimaCanInterpolateMultipleValues(t *testing.T) {
+	x := []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 6.2}
+	y := []float64{3.37, 4.45, 4.81, 3.96, 3.31, 2.72, 3.02, 3.43, 4.07}
+
+	a := akima.New()
+	a.Fit(x, y)
+	estimates, err := interpolate.WithMulti(a, x)
+	assert.Nil(t, err)
+	for i := range estimates {
+		assert.InDelta(t, y[i], estimates[i], 1e-12)
+	}
+}
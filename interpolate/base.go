@@ -2,15 +2,40 @@ package interpolate
 
 import (
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/53jk1/pok2"
 )
 
+// Extrapolation określa, jak traktowane są wartości spoza zakresu [XYPairs[0].X, XYPairs[n-1].X].
+type Extrapolation int
+
+const (
+	// ExtrapolateError odrzuca wartości spoza zakresu błędem z Validate. Jest to zachowanie domyślne.
+	ExtrapolateError Extrapolation = iota
+	// ExtrapolateClamp zwraca wartość Y najbliższego węzła brzegowego.
+	ExtrapolateClamp
+	// ExtrapolateLinear przedłuża prostą wyznaczoną przez skrajny przedział.
+	ExtrapolateLinear
+	// ExtrapolateConstant zwraca stałą wartość Base.FillValue.
+	ExtrapolateConstant
+	// ExtrapolatePeriodic przenosi wartość do zakresu z okresem XYPairs[n-1].X - XYPairs[0].X.
+	ExtrapolatePeriodic
+)
+
 // Typ podstawowy zapewnia podstawową funkcjonalność dla dowolnego typu interpolacji
 type Base struct {
 	XYPairs []pok2.CoordinatePair
 	X       []float64
 	Y       []float64
+
+	// Extrapolation to polityka dla wartości spoza zakresu, stosowana przez Interpolate każdego interpolatora
+	// z tego modułu, a także przez WithSingle i WithMulti. Przy ExtrapolateError Interpolate nie sprawdza zakresu;
+	// wartości spoza niego odrzuca dopiero Validate.
+	Extrapolation Extrapolation
+	// FillValue to wartość zwracana poza zakresem dla ExtrapolateConstant
+	FillValue float64
 }
 
 // Fit otrzymuje dwa wycinki float64 - dla współrzędnych x i y, gdzie x[i] i y[i] reprezentują parę współrzędnych w siatce.
@@ -25,3 +50,181 @@ func (b *Base) Fit(x, y []float64) error {
 	pok2.SortCoordinatePairs(b.XYPairs)
 	return nil
 }
+
+// Segment wyszukuje binarnie w posortowanych XYPairs indeks i taki, że XYPairs[i].X <= val <= XYPairs[i+1].X.
+// Dla wartości spoza zakresu zwracany jest indeks pierwszego lub ostatniego przedziału.
+func (b *Base) Segment(val float64) int {
+	return segment(len(b.XYPairs), func(i int) float64 { return b.XYPairs[i].X }, val)
+}
+
+// segment wyszukuje binarnie w rosnącym ciągu n wartości x(i) indeks przedziału zawierającego val,
+// ograniczony do [0, n-2].
+func segment(n int, x func(int) float64, val float64) int {
+	i := sort.Search(n, func(i int) bool {
+		return x(i) > val
+	}) - 1
+
+	if i > n-2 {
+		i = n - 2
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// ValidateRange sprawdza, czy wartość mieści się w zakresie węzłów.
+// Dla polityki innej niż ExtrapolateError wartości spoza zakresu są dopuszczalne.
+func (b *Base) ValidateRange(val float64) error {
+	n := len(b.XYPairs)
+	if n == 0 {
+		return fmt.Errorf("Brak punktów do interpolacji")
+	}
+
+	if b.Extrapolation != ExtrapolateError {
+		return nil
+	}
+
+	if val < b.XYPairs[0].X {
+		return fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie")
+	}
+
+	if val > b.XYPairs[n-1].X {
+		return fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie")
+	}
+
+	return nil
+}
+
+// Extrapolate wyznacza oszacowanie dla wartości spoza zakresu węzłów zgodnie z polityką Extrapolation.
+// Funkcja interpolate jest używana dla ExtrapolatePeriodic po przeniesieniu wartości do zakresu.
+// Drugi wynik jest false, jeśli wartość mieści się w zakresie lub polityką jest ExtrapolateError.
+func (b *Base) Extrapolate(val float64, interpolate func(float64) float64) (float64, bool) {
+	n := len(b.XYPairs)
+	if n == 0 || b.Extrapolation == ExtrapolateError {
+		return 0, false
+	}
+
+	first, last := b.XYPairs[0], b.XYPairs[n-1]
+	if val >= first.X && val <= last.X {
+		return 0, false
+	}
+
+	// Dla jednego węzła nie da się wyznaczyć nachylenia ani okresu
+	if n == 1 && b.Extrapolation != ExtrapolateConstant {
+		return first.Y, true
+	}
+
+	switch b.Extrapolation {
+	case ExtrapolateClamp:
+		if val < first.X {
+			return first.Y, true
+		}
+		return last.Y, true
+	case ExtrapolateLinear:
+		l, r := b.endSegment(val)
+		return l.Y + (r.Y-l.Y)/(r.X-l.X)*(val-l.X), true
+	case ExtrapolateConstant:
+		return b.FillValue, true
+	case ExtrapolatePeriodic:
+		return interpolate(b.wrap(val)), true
+	}
+
+	return 0, false
+}
+
+// endSegment zwraca węzły skrajnego przedziału po tej stronie zakresu, po której leży val
+func (b *Base) endSegment(val float64) (pok2.CoordinatePair, pok2.CoordinatePair) {
+	n := len(b.XYPairs)
+	if val > b.XYPairs[n-1].X {
+		return b.XYPairs[n-2], b.XYPairs[n-1]
+	}
+	return b.XYPairs[0], b.XYPairs[1]
+}
+
+// wrap przenosi val do zakresu węzłów z okresem XYPairs[n-1].X - XYPairs[0].X
+func (b *Base) wrap(val float64) float64 {
+	first, last := b.XYPairs[0].X, b.XYPairs[len(b.XYPairs)-1].X
+	t := math.Mod(val-first, last-first)
+	if t < 0 {
+		t += last - first
+	}
+	return first + t
+}
+
+// DerivativeOf wyznacza pochodną rzędu order w punkcie x. Dla punktów z zakresu węzłów używa funkcji inner,
+// a poza nim stosuje politykę Extrapolation: pochodna wartości stałej jest zerowa, prosta ma stałe nachylenie,
+// a dla ExtrapolatePeriodic punkt przenoszony jest do zakresu. Pochodna rzędu 0 to wartość interpolanta.
+// Zwraca błąd, jeśli rząd jest ujemny.
+func (b *Base) DerivativeOf(x float64, order int, inner func(float64, int) float64) (float64, error) {
+	if order < 0 {
+		return 0, fmt.Errorf("Rząd pochodnej nie może być ujemny")
+	}
+
+	n := len(b.XYPairs)
+	if b.Extrapolation == ExtrapolateError || (x >= b.XYPairs[0].X && x <= b.XYPairs[n-1].X) {
+		return inner(x, order), nil
+	}
+
+	if order == 0 {
+		est, _ := b.Extrapolate(x, func(v float64) float64 { return inner(v, 0) })
+		return est, nil
+	}
+
+	switch {
+	case n == 1:
+	case b.Extrapolation == ExtrapolateLinear && order == 1:
+		l, r := b.endSegment(x)
+		return (r.Y - l.Y) / (r.X - l.X), nil
+	case b.Extrapolation == ExtrapolatePeriodic:
+		return inner(b.wrap(x), order), nil
+	}
+
+	return 0, nil
+}
+
+// IntegralOf wyznacza całkę oznaczoną od a do bb. Dla przedziałów zawartych w zakresie węzłów używa funkcji inner
+// (wywoływanej zawsze z lo <= hi), a poza nim całkuje funkcję wynikającą z polityki Extrapolation.
+func (b *Base) IntegralOf(a, bb float64, inner func(lo, hi float64) float64) float64 {
+	if a > bb {
+		return -b.IntegralOf(bb, a, inner)
+	}
+
+	n := len(b.XYPairs)
+	first, last := b.XYPairs[0], b.XYPairs[n-1]
+
+	if n == 1 {
+		if b.Extrapolation == ExtrapolateConstant {
+			return b.FillValue * (bb - a)
+		}
+		return first.Y * (bb - a)
+	}
+
+	if b.Extrapolation == ExtrapolatePeriodic {
+		// F(x) to całka od first.X do x: pełne okresy plus reszta wewnątrz zakresu
+		period := last.X - first.X
+		full := inner(first.X, last.X)
+		antiderivative := func(x float64) float64 {
+			k := math.Floor((x - first.X) / period)
+			return k*full + inner(first.X, b.wrap(x))
+		}
+		return antiderivative(bb) - antiderivative(a)
+	}
+
+	var sum float64
+	if lo, hi := math.Max(a, first.X), math.Min(bb, last.X); lo < hi {
+		sum += inner(lo, hi)
+	}
+
+	// Poza zakresem ekstrapolacja jest liniowa lub stała, więc wzór prostokątów w środku przedziału jest dokładny
+	if hi := math.Min(bb, first.X); a < hi {
+		est, _ := b.Extrapolate((a+hi)/2, nil)
+		sum += est * (hi - a)
+	}
+	if lo := math.Max(a, last.X); lo < bb {
+		est, _ := b.Extrapolate((lo+bb)/2, nil)
+		sum += est * (bb - lo)
+	}
+
+	return sum
+}
@@ -0,0 +1,129 @@
+package interpolate
+
+import (
+	"math"
+
+	"github.com/53jk1/pok2"
+)
+
+// Differentiator to interpolant, który wyznacza analitycznie pochodne dowolnego rzędu
+type Differentiator interface {
+	Derivative(x float64, order int) (float64, error)
+}
+
+// Integrator to interpolant, który wyznacza analitycznie całkę oznaczoną
+type Integrator interface {
+	Integrate(a, b float64) (float64, error)
+}
+
+// Piecewise to funkcja kawałkami wielomianowa: na przedziale [Breaks[i], Breaks[i+1]] ma postać
+// Coef[i][0] + Coef[i][1]*t + Coef[i][2]*t^2 + ..., gdzie t = x - Breaks[i].
+// Poza zakresem Breaks przedłużane są wielomiany skrajnych przedziałów.
+type Piecewise struct {
+	Breaks []float64
+	Coef   [][]float64
+}
+
+// NewHermite zwraca sześcienny interpolant Hermite'a przechodzący przez posortowane punkty xy z pochodnymi d w węzłach.
+func NewHermite(xy []pok2.CoordinatePair, d []float64) *Piecewise {
+	n := len(xy)
+	p := &Piecewise{Breaks: make([]float64, n), Coef: make([][]float64, n-1)}
+	for i := range xy {
+		p.Breaks[i] = xy[i].X
+	}
+	for i := 0; i < n-1; i++ {
+		h := xy[i+1].X - xy[i].X
+		delta := (xy[i+1].Y - xy[i].Y) / h
+		p.Coef[i] = []float64{
+			xy[i].Y,
+			d[i],
+			(3*delta - 2*d[i] - d[i+1]) / h,
+			(d[i] + d[i+1] - 2*delta) / (h * h),
+		}
+	}
+	return p
+}
+
+func (p *Piecewise) segment(x float64) int {
+	return segment(len(p.Breaks), func(i int) float64 { return p.Breaks[i] }, x)
+}
+
+// Eval zwraca wartość funkcji w punkcie x.
+func (p *Piecewise) Eval(x float64) float64 {
+	return p.Derivative(x, 0)
+}
+
+// Derivative zwraca pochodną rzędu order w punkcie x. W węźle wewnętrznym używany jest wielomian przedziału na prawo od węzła.
+func (p *Piecewise) Derivative(x float64, order int) float64 {
+	i := p.segment(x)
+	c := p.Coef[i]
+	t := x - p.Breaks[i]
+
+	// Schemat Hornera dla sum(c[k] * k!/(k-order)! * t^(k-order))
+	var r float64
+	for k := len(c) - 1; k >= order; k-- {
+		f := 1.0
+		for j := k - order + 1; j <= k; j++ {
+			f *= float64(j)
+		}
+		r = r*t + c[k]*f
+	}
+	return r
+}
+
+// Integrate zwraca całkę oznaczoną od a do b.
+func (p *Piecewise) Integrate(a, b float64) float64 {
+	if a > b {
+		return -p.Integrate(b, a)
+	}
+
+	i, j := p.segment(a), p.segment(b)
+	sum := p.antiderivative(j, b-p.Breaks[j]) - p.antiderivative(i, a-p.Breaks[i])
+	for k := i; k < j; k++ {
+		sum += p.antiderivative(k, p.Breaks[k+1]-p.Breaks[k])
+	}
+	return sum
+}
+
+// antiderivative zwraca całkę wielomianu przedziału i od 0 do t
+func (p *Piecewise) antiderivative(i int, t float64) float64 {
+	c := p.Coef[i]
+	var r float64
+	for k := len(c) - 1; k >= 0; k-- {
+		r = r*t + c[k]/float64(k+1)
+	}
+	return r * t
+}
+
+// GaussLegendre zwraca przybliżenie całki funkcji f od a do b kwadraturą Gaussa-Legendre'a z n węzłami.
+// Kwadratura jest dokładna dla wielomianów stopnia co najwyżej 2n-1.
+func GaussLegendre(f func(float64) float64, a, b float64, n int) float64 {
+	mid, half := (a+b)/2, (b-a)/2
+
+	var sum float64
+	for i := 0; i < (n+1)/2; i++ {
+		// Pierwiastek wielomianu Legendre'a P_n metodą Newtona, zaczynając od przybliżenia Czebyszewa
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, 0.0
+			for j := 1; j <= n; j++ {
+				p0, p1 = ((2*float64(j)-1)*z*p0-(float64(j)-1)*p1)/float64(j), p0
+			}
+			dp = float64(n) * (z*p0 - p1) / (z*z - 1)
+			dz := p0 / dp
+			z -= dz
+			if math.Abs(dz) <= 1e-15 {
+				break
+			}
+		}
+
+		w := 2 / ((1 - z*z) * dp * dp)
+		if 2*i+1 == n {
+			sum += w * f(mid)
+			continue
+		}
+		sum += w * (f(mid-half*z) + f(mid+half*z))
+	}
+	return half * sum
+}
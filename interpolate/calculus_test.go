@@ -0,0 +1,166 @@
+package interpolate_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/akima"
+	"github.com/53jk1/pok2/interpolate/lagrange"
+	"github.com/53jk1/pok2/interpolate/linear"
+	"github.com/53jk1/pok2/interpolate/newton"
+	"github.com/53jk1/pok2/interpolate/pchip"
+	"github.com/53jk1/pok2/interpolate/spline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPiecewise(t *testing.T) {
+	// x^2 na [0, 1] i 1 + 2(x-1) na [1, 3]
+	pp := &interpolate.Piecewise{
+		Breaks: []float64{0, 1, 3},
+		Coef:   [][]float64{{0, 0, 1}, {1, 2}},
+	}
+
+	assert.InDelta(t, 0.25, pp.Eval(0.5), 1e-15)
+	assert.InDelta(t, 4, pp.Eval(2.5), 1e-15)
+	assert.InDelta(t, 1, pp.Derivative(0.5, 1), 1e-15)
+	assert.InDelta(t, 2, pp.Derivative(0.5, 2), 1e-15)
+	assert.InDelta(t, 0, pp.Derivative(0.5, 3), 1e-15)
+	assert.InDelta(t, 2, pp.Derivative(1, 1), 1e-15)
+	assert.InDelta(t, 1.0/3+3.75, pp.Integrate(0, 2.5), 1e-14)
+	assert.InDelta(t, -(1.0/3 + 3.75), pp.Integrate(2.5, 0), 1e-14)
+	assert.InDelta(t, (1-0.125)/3, pp.Integrate(0.5, 1), 1e-14)
+}
+
+func TestGaussLegendre(t *testing.T) {
+	cases := map[string]struct {
+		f        func(float64) float64
+		a, b     float64
+		n        int
+		expected float64
+	}{
+		"single node is the midpoint rule": {
+			f:        func(x float64) float64 { return 3*x + 1 },
+			a:        0,
+			b:        2,
+			n:        1,
+			expected: 8,
+		},
+		"exact for degree 2n-1": {
+			f:        func(x float64) float64 { return math.Pow(x, 9) - 2*math.Pow(x, 4) },
+			a:        -1,
+			b:        2,
+			n:        5,
+			expected: (math.Pow(2, 10)-1)/10 - 2*(math.Pow(2, 5)+1)/5,
+		},
+		"smooth function": {
+			f:        math.Exp,
+			a:        0,
+			b:        1,
+			n:        10,
+			expected: math.E - 1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, c.expected, interpolate.GaussLegendre(c.f, c.a, c.b, c.n), 1e-12)
+		})
+	}
+}
+
+func TestEveryInterpolatorDifferentiatesAndIntegratesALine(t *testing.T) {
+	type calculusInterpolator interface {
+		interpolate.Interpolator
+		interpolate.Differentiator
+		interpolate.Integrator
+	}
+
+	cases := map[string]struct {
+		interpolator calculusInterpolator
+	}{
+		"linear":   {linear.New()},
+		"lagrange": {lagrange.New()},
+		"newton":   {newton.New()},
+		"spline":   {spline.New()},
+		"pchip":    {pchip.New()},
+		"akima":    {akima.New()},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.interpolator.Fit([]float64{0, 1, 2.5, 3, 5}, []float64{1, 3, 6, 7, 11})
+			assert.Nil(t, err)
+
+			for _, x := range []float64{0, 0.7, 2.5, 4.2, 5} {
+				d, err := c.interpolator.Derivative(x, 1)
+				assert.Nil(t, err)
+				assert.InDelta(t, 2, d, 1e-9)
+
+				d, err = c.interpolator.Derivative(x, 2)
+				assert.Nil(t, err)
+				assert.InDelta(t, 0, d, 1e-9)
+			}
+
+			area, err := c.interpolator.Integrate(0.5, 4)
+			assert.Nil(t, err)
+			assert.InDelta(t, 19.25, area, 1e-9)
+
+			_, err = c.interpolator.Derivative(0, -1)
+			assert.Equal(t, fmt.Errorf("Rząd pochodnej nie może być ujemny"), err)
+
+			_, err = c.interpolator.Integrate(-1, 4)
+			assert.Equal(t, fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"), err)
+		})
+	}
+}
+
+func TestCalculusExtrapolation(t *testing.T) {
+	cases := map[string]struct {
+		extrapolation      interpolate.Extrapolation
+		expectedDerivative float64
+		expectedIntegral   float64
+	}{
+		"clamp": {
+			extrapolation:      interpolate.ExtrapolateClamp,
+			expectedDerivative: 0,
+			// 1 na [-2, 0], odcinki na [0, 4] i 7 na [4, 6]
+			expectedIntegral: 2 + 14 + 14,
+		},
+		"linear": {
+			extrapolation:      interpolate.ExtrapolateLinear,
+			expectedDerivative: 2,
+			expectedIntegral:   0 + 14 + 18,
+		},
+		"constant": {
+			extrapolation:      interpolate.ExtrapolateConstant,
+			expectedDerivative: 0,
+			expectedIntegral:   -1*4 + 14,
+		},
+		"periodic": {
+			extrapolation:      interpolate.ExtrapolatePeriodic,
+			expectedDerivative: 1,
+			// [-2, 0] odpowiada [2, 4], a [4, 6] odpowiada [0, 2]
+			expectedIntegral: 10 + 14 + 4,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			li := linear.New()
+			li.Extrapolation = c.extrapolation
+			li.FillValue = -1
+			err := li.Fit([]float64{0, 1, 2, 4}, []float64{1, 2, 3, 7})
+			assert.Nil(t, err)
+
+			d, err := li.Derivative(4.5, 1)
+			assert.Nil(t, err)
+			assert.InDelta(t, c.expectedDerivative, d, 1e-12)
+
+			area, err := li.Integrate(-2, 6)
+			assert.Nil(t, err)
+			assert.InDelta(t, c.expectedIntegral, area, 1e-12)
+		})
+	}
+}
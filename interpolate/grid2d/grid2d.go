@@ -0,0 +1,228 @@
+package grid2d
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/53jk1/pok2"
+)
+
+// Method określa sposób interpolacji wewnątrz komórki siatki
+type Method int
+
+const (
+	// Bilinear to interpolacja dwuliniowa z czterech narożników komórki
+	Bilinear Method = iota
+	// Bicubic to dwusześcienna interpolacja Hermite'a z pochodnymi wyznaczonymi różnicami skończonymi
+	Bicubic
+)
+
+// Grid zapewnia interpolację na prostokątnej siatce (X, Y) z wartościami w macierzy Z,
+// gdzie Z[i][j] jest wartością funkcji w punkcie (X[i], Y[j]).
+// Metoda interpolacji ustalana jest w konstruktorze, ponieważ od niej zależy, co wyznacza Fit.
+type Grid struct {
+	X []float64
+	Y []float64
+	Z pok2.Matrix
+
+	method Method
+
+	// zx, zy i zxy przechowują pochodne cząstkowe w węzłach, wyznaczane tylko dla Bicubic
+	zx, zy, zxy pok2.Matrix
+	fitted      bool
+}
+
+// New zwraca nowy obiekt Grid z interpolacją dwuliniową.
+func New() *Grid {
+	g := &Grid{method: Bilinear}
+	return g
+}
+
+// NewBicubic zwraca nowy obiekt Grid z interpolacją dwusześcienną.
+func NewBicubic() *Grid {
+	g := &Grid{method: Bicubic}
+	return g
+}
+
+// Fit otrzymuje osie x i y oraz macierz wartości z o wymiarach len(x) x len(y).
+// Zwraca błąd, jeśli macierz jest pusta lub poszarpana, jej wymiary nie pasują do osi,
+// na którejś osi są mniej niż dwa punkty lub wartości osi nie są ściśle rosnące.
+func (g *Grid) Fit(x, y []float64, z pok2.Matrix) error {
+	g.fitted = false
+	g.zx, g.zy, g.zxy = nil, nil, nil
+
+	if err := z.Validate(); err != nil {
+		return err
+	}
+
+	rows, cols := z.Dim()
+	if rows != len(x) || cols != len(y) {
+		return fmt.Errorf("Wymiary macierzy Z (%dx%d) nie pasują do osi X (%d) i Y (%d)", rows, cols, len(x), len(y))
+	}
+
+	if err := validateAxis(x, "X"); err != nil {
+		return err
+	}
+	if err := validateAxis(y, "Y"); err != nil {
+		return err
+	}
+
+	g.X = append([]float64{}, x...)
+	g.Y = append([]float64{}, y...)
+	g.Z = make(pok2.Matrix, rows)
+	for i := range z {
+		g.Z[i] = append(pok2.Vector{}, z[i]...)
+	}
+
+	if g.method == Bicubic {
+		g.fitDerivatives()
+	}
+
+	g.fitted = true
+	return nil
+}
+
+func validateAxis(axis []float64, name string) error {
+	if len(axis) < 2 {
+		return fmt.Errorf("Na osi %s potrzebne są co najmniej 2 punkty", name)
+	}
+	for i := 1; i < len(axis); i++ {
+		if axis[i] <= axis[i-1] {
+			return fmt.Errorf("Wartości osi %s muszą być ściśle rosnące", name)
+		}
+	}
+	return nil
+}
+
+// fitDerivatives wyznacza pochodne cząstkowe w węzłach z różnic centralnych,
+// a na brzegach siatki z różnic jednostronnych.
+func (g *Grid) fitDerivatives() {
+	n, m := len(g.X), len(g.Y)
+	g.zx = make(pok2.Matrix, n)
+	g.zy = make(pok2.Matrix, n)
+	g.zxy = make(pok2.Matrix, n)
+
+	for i := 0; i < n; i++ {
+		g.zx[i] = make(pok2.Vector, m)
+		g.zy[i] = make(pok2.Vector, m)
+		g.zxy[i] = make(pok2.Vector, m)
+
+		il, ir := neighbours(i, n)
+		for j := 0; j < m; j++ {
+			jl, jr := neighbours(j, m)
+			dx := g.X[ir] - g.X[il]
+			dy := g.Y[jr] - g.Y[jl]
+
+			g.zx[i][j] = (g.Z[ir][j] - g.Z[il][j]) / dx
+			g.zy[i][j] = (g.Z[i][jr] - g.Z[i][jl]) / dy
+			g.zxy[i][j] = (g.Z[ir][jr] - g.Z[ir][jl] - g.Z[il][jr] + g.Z[il][jl]) / (dx * dy)
+		}
+	}
+}
+
+// neighbours zwraca indeksy sąsiadów węzła i używane w różnicach skończonych
+func neighbours(i, n int) (int, int) {
+	l, r := i-1, i+1
+	if l < 0 {
+		l = 0
+	}
+	if r > n-1 {
+		r = n - 1
+	}
+	return l, r
+}
+
+// segment wyszukuje binarnie indeks i taki, że axis[i] <= val <= axis[i+1]
+func segment(axis []float64, val float64) int {
+	n := len(axis)
+	i := sort.Search(n, func(i int) bool {
+		return axis[i] > val
+	}) - 1
+
+	if i > n-2 {
+		i = n - 2
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+func (g *Grid) Interpolate(x, y float64) float64 {
+	i := segment(g.X, x)
+	j := segment(g.Y, y)
+
+	hx := g.X[i+1] - g.X[i]
+	hy := g.Y[j+1] - g.Y[j]
+	t := (x - g.X[i]) / hx
+	u := (y - g.Y[j]) / hy
+
+	if g.method == Bilinear {
+		return (1-t)*(1-u)*g.Z[i][j] + t*(1-u)*g.Z[i+1][j] + (1-t)*u*g.Z[i][j+1] + t*u*g.Z[i+1][j+1]
+	}
+
+	// Wielomiany bazowe Hermite'a: wx[a] dla wartości, dx[a] dla pochodnej w węźle a komórki
+	wx := [2]float64{(1 + 2*t) * (1 - t) * (1 - t), t * t * (3 - 2*t)}
+	dx := [2]float64{hx * t * (1 - t) * (1 - t), hx * t * t * (t - 1)}
+	wy := [2]float64{(1 + 2*u) * (1 - u) * (1 - u), u * u * (3 - 2*u)}
+	dy := [2]float64{hy * u * (1 - u) * (1 - u), hy * u * u * (u - 1)}
+
+	var est float64
+	for a := 0; a < 2; a++ {
+		for b := 0; b < 2; b++ {
+			est += wx[a]*wy[b]*g.Z[i+a][j+b] +
+				dx[a]*wy[b]*g.zx[i+a][j+b] +
+				wx[a]*dy[b]*g.zy[i+a][j+b] +
+				dx[a]*dy[b]*g.zxy[i+a][j+b]
+		}
+	}
+	return est
+}
+
+func (g *Grid) Validate(x, y float64) error {
+
+	if !g.fitted {
+		return fmt.Errorf("Siatka nie została dopasowana")
+	}
+
+	if x < g.X[0] || y < g.Y[0] {
+		return fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie")
+	}
+
+	if x > g.X[len(g.X)-1] || y > g.Y[len(g.Y)-1] {
+		return fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie")
+	}
+
+	return nil
+}
+
+// WithSingle zwraca interpolowaną wartość w punkcie (x, y) oraz błąd
+func WithSingle(g *Grid, x, y float64) (float64, error) {
+	var est float64
+
+	err := g.Validate(x, y)
+	if err != nil {
+		return est, err
+	}
+
+	est = g.Interpolate(x, y)
+	return est, nil
+}
+
+// WithMulti zwraca interpolowane wartości w punktach (x[k], y[k]) oraz błąd.
+// Zwraca błąd, jeśli rozmiary x i y nie są zgodne.
+func WithMulti(g *Grid, x, y []float64) ([]float64, error) {
+	var r []float64
+	if len(x) != len(y) {
+		return r, fmt.Errorf("Rozmiary X i Y nie pasują")
+	}
+
+	for k := range x {
+		est, err := WithSingle(g, x[k], y[k])
+		if err != nil {
+			return r, err
+		}
+		r = append(r, est)
+	}
+	return r, nil
+}
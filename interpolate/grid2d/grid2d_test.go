@@ -0,0 +1,183 @@
+package grid2d_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/interpolate/grid2d"
+	"github.com/stretchr/testify/assert"
+)
+
+func tabulate(x, y []float64, f func(x, y float64) float64) pok2.Matrix {
+	z := make(pok2.Matrix, len(x))
+	for i := range x {
+		z[i] = make(pok2.Vector, len(y))
+		for j := range y {
+			z[i][j] = f(x[i], y[j])
+		}
+	}
+	return z
+}
+
+func TestGridCanFit(t *testing.T) {
+	cases := map[string]struct {
+		x             []float64
+		y             []float64
+		z             pok2.Matrix
+		expectedError error
+	}{
+		"basic grid fit": {
+			x:             []float64{0, 1, 2},
+			y:             []float64{0, 10},
+			z:             pok2.Matrix{{1, 2}, {3, 4}, {5, 6}},
+			expectedError: nil,
+		},
+		"empty z": {
+			x:             []float64{0, 1},
+			y:             []float64{0, 1},
+			z:             pok2.Matrix{},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"ragged z": {
+			x:             []float64{0, 1},
+			y:             []float64{0, 1},
+			z:             pok2.Matrix{{1, 2}, {3}},
+			expectedError: &pok2.RaggedError{Row: 1, Len: 1, Cols: 2},
+		},
+		"z does not match axes": {
+			x:             []float64{0, 1, 2},
+			y:             []float64{0, 1},
+			z:             pok2.Matrix{{1, 2, 3}, {4, 5, 6}},
+			expectedError: fmt.Errorf("Wymiary macierzy Z (2x3) nie pasują do osi X (3) i Y (2)"),
+		},
+		"single point on axis": {
+			x:             []float64{0},
+			y:             []float64{0, 1},
+			z:             pok2.Matrix{{1, 2}},
+			expectedError: fmt.Errorf("Na osi X potrzebne są co najmniej 2 punkty"),
+		},
+		"axis not increasing": {
+			x:             []float64{0, 1},
+			y:             []float64{0, 2, 1},
+			z:             pok2.Matrix{{1, 2, 3}, {4, 5, 6}},
+			expectedError: fmt.Errorf("Wartości osi Y muszą być ściśle rosnące"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, g := range []*grid2d.Grid{grid2d.New(), grid2d.NewBicubic()} {
+				err := g.Fit(c.x, c.y, c.z)
+				assert.Equal(t, c.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestGridCanInterpolateSingleValue(t *testing.T) {
+	bilinear := func(x, y float64) float64 {
+		return 1 + 2*x + 3*y + x*y
+	}
+	x := []float64{0, 0.5, 2, 3}
+	y := []float64{-1, 0, 4}
+
+	cases := map[string]struct {
+		grid             *grid2d.Grid
+		x, y             float64
+		expectedEstimate float64
+		expectedError    error
+	}{
+		"bilinear reproduces a bilinear function": {
+			grid:             grid2d.New(),
+			x:                1.3,
+			y:                2.2,
+			expectedEstimate: bilinear(1.3, 2.2),
+		},
+		"bicubic reproduces a bilinear function": {
+			grid:             grid2d.NewBicubic(),
+			x:                1.3,
+			y:                2.2,
+			expectedEstimate: bilinear(1.3, 2.2),
+		},
+		"bicubic at grid corner": {
+			grid:             grid2d.NewBicubic(),
+			x:                3,
+			y:                4,
+			expectedEstimate: bilinear(3, 4),
+		},
+		"bilinear on grid line": {
+			grid:             grid2d.New(),
+			x:                0.5,
+			y:                -0.25,
+			expectedEstimate: bilinear(0.5, -0.25),
+		},
+		"too small value to interpolate": {
+			grid:          grid2d.New(),
+			x:             1,
+			y:             -1.5,
+			expectedError: fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+		"too big value to interpolate": {
+			grid:          grid2d.NewBicubic(),
+			x:             3.5,
+			y:             0,
+			expectedError: fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.grid.Fit(x, y, tabulate(x, y, bilinear))
+			assert.Nil(t, err)
+
+			estimate, err := grid2d.WithSingle(c.grid, c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
+		})
+	}
+}
+
+func TestGridBicubicIsMoreAccurateThanBilinear(t *testing.T) {
+	f := func(x, y float64) float64 {
+		return math.Sin(x) * math.Cos(y)
+	}
+
+	x := make([]float64, 11)
+	y := make([]float64, 11)
+	for i := range x {
+		x[i] = float64(i) * 0.3
+		y[i] = float64(i) * 0.3
+	}
+
+	bilinear := grid2d.New()
+	assert.Nil(t, bilinear.Fit(x, y, tabulate(x, y, f)))
+	bicubic := grid2d.NewBicubic()
+	assert.Nil(t, bicubic.Fit(x, y, tabulate(x, y, f)))
+
+	px := []float64{0.45, 1.1, 1.65, 2.2, 2.85}
+	py := []float64{2.7, 0.15, 1.35, 0.8, 2.05}
+
+	linearEstimates, err := grid2d.WithMulti(bilinear, px, py)
+	assert.Nil(t, err)
+	cubicEstimates, err := grid2d.WithMulti(bicubic, px, py)
+	assert.Nil(t, err)
+
+	var linearErr, cubicErr float64
+	for k := range px {
+		linearErr = math.Max(linearErr, math.Abs(linearEstimates[k]-f(px[k], py[k])))
+		cubicErr = math.Max(cubicErr, math.Abs(cubicEstimates[k]-f(px[k], py[k])))
+	}
+	assert.True(t, cubicErr < linearErr/2)
+	assert.InDelta(t, 0, cubicErr, 1e-2)
+}
+
+func TestGridValidate(t *testing.T) {
+	g := grid2d.New()
+	assert.Equal(t, fmt.Errorf("Siatka nie została dopasowana"), g.Validate(0, 0))
+
+	assert.Nil(t, g.Fit([]float64{0, 1}, []float64{0, 1}, pok2.Matrix{{0, 1}, {1, 2}}))
+	_, err := grid2d.WithMulti(g, []float64{0, 1}, []float64{0})
+	assert.Equal(t, fmt.Errorf("Rozmiary X i Y nie pasują"), err)
+}
@@ -1,20 +1,30 @@
 package interpolate
 
-type interpolator interface {
+// Validator sprawdza, czy dla danej wartości można wyznaczyć oszacowanie
+type Validator interface {
+	Validate(float64) error
+}
+
+// ValidateInterpolator to minimalny interfejs akceptowany przez WithSingle i WithMulti
+type ValidateInterpolator interface {
 	Interpolate(float64) float64
+	Validator
 }
 
-type validator interface {
-	Validate(float64) error
+// Interpolator to interfejs spełniany przez każdą metodę interpolacji z tego modułu.
+// Własne typy spełniające ten interfejs mogą być używane z WithSingle, WithMulti i rejestrowane przez Register.
+type Interpolator interface {
+	Fit(x, y []float64) error
+	ValidateInterpolator
 }
 
-type validateInterpolator interface {
-	interpolator
-	validator
+// extrapolator jest spełniany przez każdy typ osadzający Base
+type extrapolator interface {
+	Extrapolate(float64, func(float64) float64) (float64, bool)
 }
 
 // WithMulti akceptuje wycinek float64 i zwraca interpolowane wartości dla przekazanych wartości wycinka, a błąd
-func WithMulti(vi validateInterpolator, vals []float64) ([]float64, error) {
+func WithMulti(vi ValidateInterpolator, vals []float64) ([]float64, error) {
 	var r []float64
 	for _, val := range vals {
 		est, err := WithSingle(vi, val)
@@ -27,7 +37,7 @@ func WithMulti(vi validateInterpolator, vals []float64) ([]float64, error) {
 }
 
 // WithSingle akceptuje pojedynczą wartość float64 i zwraca dla niej interpolowaną wartość oraz błąd
-func WithSingle(vi validateInterpolator, val float64) (float64, error) {
+func WithSingle(vi ValidateInterpolator, val float64) (float64, error) {
 	var est float64
 
 	err := vi.Validate(val)
@@ -35,6 +45,12 @@ func WithSingle(vi validateInterpolator, val float64) (float64, error) {
 		return est, err
 	}
 
+	if e, ok := vi.(extrapolator); ok {
+		if est, ok := e.Extrapolate(val, vi.Interpolate); ok {
+			return est, nil
+		}
+	}
+
 	est = vi.Interpolate(val)
 	return est, nil
 }
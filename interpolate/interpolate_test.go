@@ -0,0 +1,156 @@
+package interpolate_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/akima"
+	"github.com/53jk1/pok2/interpolate/lagrange"
+	"github.com/53jk1/pok2/interpolate/linear"
+	"github.com/53jk1/pok2/interpolate/newton"
+	"github.com/53jk1/pok2/interpolate/pchip"
+	"github.com/53jk1/pok2/interpolate/spline"
+	"github.com/53jk1/pok2/interpolate/step"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSingleExtrapolation(t *testing.T) {
+	cases := map[string]struct {
+		extrapolation      interpolate.Extrapolation
+		fillValue          float64
+		valueToInterpolate float64
+		expectedEstimate   float64
+		expectedError      error
+	}{
+		"error below range": {
+			extrapolation:      interpolate.ExtrapolateError,
+			valueToInterpolate: -1,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+		"error above range": {
+			extrapolation:      interpolate.ExtrapolateError,
+			valueToInterpolate: 5,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+		"clamp below range": {
+			extrapolation:      interpolate.ExtrapolateClamp,
+			valueToInterpolate: -1,
+			expectedEstimate:   1,
+		},
+		"clamp above range": {
+			extrapolation:      interpolate.ExtrapolateClamp,
+			valueToInterpolate: 5,
+			expectedEstimate:   7,
+		},
+		"linear below range": {
+			extrapolation:      interpolate.ExtrapolateLinear,
+			valueToInterpolate: -1,
+			expectedEstimate:   0,
+		},
+		"linear above range": {
+			extrapolation:      interpolate.ExtrapolateLinear,
+			valueToInterpolate: 5,
+			expectedEstimate:   9,
+		},
+		"constant fill": {
+			extrapolation:      interpolate.ExtrapolateConstant,
+			fillValue:          -100,
+			valueToInterpolate: 5,
+			expectedEstimate:   -100,
+		},
+		"constant fill inside range": {
+			extrapolation:      interpolate.ExtrapolateConstant,
+			fillValue:          -100,
+			valueToInterpolate: 3,
+			expectedEstimate:   5,
+		},
+		"periodic below range": {
+			extrapolation:      interpolate.ExtrapolatePeriodic,
+			valueToInterpolate: -1,
+			expectedEstimate:   5,
+		},
+		"periodic above range": {
+			extrapolation:      interpolate.ExtrapolatePeriodic,
+			valueToInterpolate: 9.5,
+			expectedEstimate:   2.5,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			li := linear.New()
+			li.Extrapolation = c.extrapolation
+			li.FillValue = c.fillValue
+			err := li.Fit([]float64{0, 1, 2, 4}, []float64{1, 2, 3, 7})
+			assert.Nil(t, err)
+
+			estimate, err := interpolate.WithSingle(li, c.valueToInterpolate)
+			assert.Equal(t, c.expectedError, err)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
+		})
+	}
+}
+
+func TestWithMultiExtrapolation(t *testing.T) {
+	s := spline.New()
+	s.Extrapolation = interpolate.ExtrapolatePeriodic
+	x := []float64{0, 1, 2, 3, 4}
+	y := []float64{0, 1, 0, -1, 0}
+	err := s.Fit(x, y)
+	assert.Nil(t, err)
+
+	estimates, err := interpolate.WithMulti(s, []float64{-3, 5, 6.5, 2.5})
+	assert.Nil(t, err)
+
+	inRange, err := interpolate.WithMulti(s, []float64{1, 1, 2.5, 2.5})
+	assert.Nil(t, err)
+	for i := range estimates {
+		assert.InDelta(t, inRange[i], estimates[i], 1e-12)
+	}
+}
+
+func TestEveryInterpolatorHonoursExtrapolation(t *testing.T) {
+	li := linear.New()
+	lg := lagrange.New()
+	nt := newton.New()
+	sp := spline.New()
+	ph := pchip.New()
+	ak := akima.New()
+	st := step.NewNearest()
+
+	cases := map[string]struct {
+		interpolator interface {
+			Fit(x, y []float64) error
+			Interpolate(float64) float64
+			Validate(float64) error
+		}
+		base *interpolate.Base
+	}{
+		"linear":   {li, &li.Base},
+		"lagrange": {lg, &lg.Base},
+		"newton":   {nt, &nt.Base},
+		"spline":   {sp, &sp.Base},
+		"pchip":    {ph, &ph.Base},
+		"akima":    {ak, &ak.Base},
+		"step":     {st, &st.Base},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			c.base.Extrapolation = interpolate.ExtrapolateClamp
+			err := c.interpolator.Fit([]float64{1, 2, 3, 4, 5}, []float64{1, 4, 9, 16, 25})
+			assert.Nil(t, err)
+
+			estimates, err := interpolate.WithMulti(c.interpolator, []float64{math.Inf(-1), 0, 6, 1e9})
+			assert.Nil(t, err)
+			assert.Equal(t, []float64{1, 1, 25, 25}, estimates)
+
+			// Interpolate wywołane bezpośrednio stosuje tę samą politykę
+			for i, val := range []float64{math.Inf(-1), 0, 6, 1e9} {
+				assert.Equal(t, estimates[i], c.interpolator.Interpolate(val))
+			}
+		})
+	}
+}
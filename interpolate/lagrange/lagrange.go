@@ -2,14 +2,26 @@ package lagrange
 
 import (
 	"fmt"
-	
+	"math"
+
 	"github.com/53jk1/pok2/interpolate"
 )
 
 // Lagrange zapewnia podstawową funkcjonalność interpolacji lagrange.
 // Biorąc pod uwagę wycinki X i Y float64, można oszacować wartość funkcji w żądanym punkcie.
+// Wielomian wyznaczany jest w postaci barycentrycznej: wagi liczone są raz podczas Fit, a każde oszacowanie kosztuje O(n).
 type Lagrange struct {
 	interpolate.Base
+	chebyshev bool
+
+	// weights przechowuje wagi barycentryczne kolejnych węzłów XYPairs
+	weights []float64
+	fitErr  error
+}
+
+func init() {
+	interpolate.Register("lagrange", func() interpolate.Interpolator { return New() })
+	interpolate.Register("lagrange-chebyshev", func() interpolate.Interpolator { return NewChebyshev() })
 }
 
 // New zwraca nowy obiekt Lagrange'a.
@@ -18,41 +30,194 @@ func New() *Lagrange {
 	return lg
 }
 
-func (lg *Lagrange) Interpolate(val float64) float64 {
-	var est float64
+// NewChebyshev zwraca nowy obiekt Lagrange'a dla węzłów Czebyszewa zwracanych przez ChebyshevNodes.
+// Wagi barycentryczne wyznaczane są wtedy ze wzoru jawnego w czasie O(n) zamiast O(n^2).
+func NewChebyshev() *Lagrange {
+	lg := &Lagrange{chebyshev: true}
+	return lg
+}
+
+// ChebyshevNodes zwraca n węzłów Czebyszewa drugiego rodzaju przeskalowanych do przedziału [a, b], w porządku rosnącym.
+// Interpolacja w tych węzłach nie wykazuje efektu Rungego, który pojawia się przy węzłach równoodległych.
+func ChebyshevNodes(n int, a, b float64) []float64 {
+	if n == 1 {
+		return []float64{(a + b) / 2}
+	}
+	nodes := make([]float64, n)
+	for j := range nodes {
+		nodes[j] = a + (b-a)*(1-math.Cos(float64(j)*math.Pi/float64(n-1)))/2
+	}
+	return nodes
+}
+
+// Fit otrzymuje wycinki współrzędnych x i y, sortuje punkty i wyznacza wagi barycentryczne.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, wartości X się powtarzają, wagi nie są skończone
+// (zbyt wiele węzłów nierozłożonych jak węzły Czebyszewa) lub, dla obiektu z NewChebyshev,
+// wartości X nie są węzłami Czebyszewa.
+func (lg *Lagrange) Fit(x, y []float64) error {
+	lg.weights = nil
+	lg.fitErr = nil
+
+	if err := lg.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(lg.XYPairs)
+	if n == 0 {
+		lg.fitErr = fmt.Errorf("Do interpolacji Lagrange'a potrzebny jest co najmniej 1 punkt")
+		return lg.fitErr
+	}
+
+	// Punkty są posortowane, więc powtórzenia sąsiadują ze sobą
+	for i := 1; i < n; i++ {
+		if lg.XYPairs[i].X == lg.XYPairs[i-1].X {
+			lg.fitErr = fmt.Errorf("Istnieją co najmniej 2 takie same wartości X. Spowoduje to dzielenie przez zero w interpolacji Lagrange'a")
+			return lg.fitErr
+		}
+	}
 
-	for i := 0; i < len(lg.X); i++ {
-		prod := lg.Y[i]
-		for j := 0; j < len(lg.X); j++ {
-			if i != j {
-				prod = prod * (val - lg.X[j]) / (lg.X[i] - lg.X[j])
+	if lg.chebyshev {
+		return lg.fitChebyshevWeights()
+	}
+
+	// w[j] = 1 / prod(x[j] - x[k]) dla k != j. Różnice mnożymy przez 4/(b-a), dzięki czemu iloczyn nie ulega
+	// niedomiarowi ani nadmiarowi przy wielu węzłach; wspólny czynnik wag skraca się we wzorze barycentrycznym.
+	scale := 1.0
+	if n > 1 {
+		scale = 4 / (lg.XYPairs[n-1].X - lg.XYPairs[0].X)
+	}
+	w := make([]float64, n)
+	for j := range w {
+		w[j] = 1
+		for k := 0; k < n; k++ {
+			if k != j {
+				w[j] /= (lg.XYPairs[j].X - lg.XYPairs[k].X) * scale
 			}
 		}
-		est += prod
+		if w[j] == 0 || math.IsInf(w[j], 0) || math.IsNaN(w[j]) {
+			lg.fitErr = fmt.Errorf("Wagi barycentryczne wykraczają poza zakres liczb zmiennoprzecinkowych dla %d węzłów", n)
+			return lg.fitErr
+		}
 	}
+	lg.weights = w
+	return nil
+}
 
-	return est
+func (lg *Lagrange) fitChebyshevWeights() error {
+	n := len(lg.XYPairs)
+	a, b := lg.XYPairs[0].X, lg.XYPairs[n-1].X
+	nodes := ChebyshevNodes(n, a, b)
+
+	for j := range nodes {
+		if math.Abs(nodes[j]-lg.XYPairs[j].X) > 1e-9*math.Max(1, b-a) {
+			lg.fitErr = fmt.Errorf("Wartości X nie są węzłami Czebyszewa")
+			return lg.fitErr
+		}
+	}
+
+	// Dla węzłów Czebyszewa drugiego rodzaju w[j] = (-1)^j, a na końcach połowa tej wartości
+	w := make([]float64, n)
+	for j := range w {
+		w[j] = 1
+		if j%2 == 1 {
+			w[j] = -1
+		}
+		if j == 0 || j == n-1 {
+			w[j] /= 2
+		}
+	}
+	lg.weights = w
+	return nil
 }
 
-func (lg *Lagrange) Validate(val float64) error {
+func (lg *Lagrange) Interpolate(val float64) float64 {
+	if est, ok := lg.Extrapolate(val, lg.Interpolate); ok {
+		return est
+	}
+	return lg.evaluate(val, func(j int) float64 { return lg.XYPairs[j].Y })
+}
+
+// evaluate wyznacza wartość w punkcie val wielomianu przyjmującego w węźle j wartość y(j)
+func (lg *Lagrange) evaluate(val float64, y func(int) float64) float64 {
+	var num, den float64
 
-	for i := 0; i < len(lg.X); i++ {
-		for j := 0; j < len(lg.X); j++ {
-			if i != j {
-				if lg.X[i]-lg.X[j] == 0 {
-					return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X. Spowoduje to dzielenie przez zero w interpolacji Lagrange'a")
+	for j, p := range lg.XYPairs {
+		diff := val - p.X
+		if diff == 0 {
+			return y(j)
+		}
+		t := lg.weights[j] / diff
+		num += t * y(j)
+		den += t
+	}
+
+	return num / den
+}
+
+// derivative wyznacza pochodną wielomianu w punkcie x. Wartości pochodnej w węzłach otrzymywane są
+// z macierzy różniczkowania D[i][j] = (w[j]/w[i]) / (x[i]-x[j]), a następnie interpolowane barycentrycznie.
+func (lg *Lagrange) derivative(x float64, order int) float64 {
+	n := len(lg.XYPairs)
+	if order == 0 {
+		return lg.Interpolate(x)
+	}
+	if order >= n {
+		return 0
+	}
+
+	v := make([]float64, n)
+	for j, p := range lg.XYPairs {
+		v[j] = p.Y
+	}
+	next := make([]float64, n)
+	for k := 0; k < order; k++ {
+		for i, pi := range lg.XYPairs {
+			// Element diagonalny D[i][i] = -sum(D[i][j]), więc sumujemy D[i][j] * (v[j] - v[i])
+			var sum float64
+			for j, pj := range lg.XYPairs {
+				if j != i {
+					sum += lg.weights[j] / lg.weights[i] / (pi.X - pj.X) * (v[j] - v[i])
 				}
 			}
+			next[i] = sum
 		}
+		v, next = next, v
+	}
+
+	return lg.evaluate(x, func(j int) float64 { return v[j] })
+}
+
+// Derivative zwraca pochodną wielomianu interpolacyjnego rzędu order w punkcie x oraz błąd (jeśli istnieje).
+func (lg *Lagrange) Derivative(x float64, order int) (float64, error) {
+	if err := lg.Validate(x); err != nil {
+		return 0, err
+	}
+	return lg.DerivativeOf(x, order, lg.derivative)
+}
+
+// Integrate zwraca całkę oznaczoną wielomianu interpolacyjnego od from do to oraz błąd (jeśli istnieje).
+// Całka liczona jest kwadraturą Gaussa-Legendre'a, dokładną dla wielomianu stopnia n-1.
+func (lg *Lagrange) Integrate(from, to float64) (float64, error) {
+	if err := lg.Validate(from); err != nil {
+		return 0, err
 	}
+	if err := lg.Validate(to); err != nil {
+		return 0, err
+	}
+	return lg.IntegralOf(from, to, func(lo, hi float64) float64 {
+		return interpolate.GaussLegendre(lg.Interpolate, lo, hi, len(lg.XYPairs)/2+1)
+	}), nil
+}
 
-	if val < lg.XYPairs[0].X {
-		return fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie")
+func (lg *Lagrange) Validate(val float64) error {
+
+	if lg.fitErr != nil {
+		return lg.fitErr
 	}
 
-	if val > lg.XYPairs[len(lg.XYPairs)-1].X {
-		return fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie")
+	if lg.weights == nil {
+		return fmt.Errorf("Interpolant Lagrange'a nie został dopasowany")
 	}
 
-	return nil
+	return lg.ValidateRange(val)
 }
@@ -2,6 +2,7 @@ package lagrange_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/53jk1/pok2/interpolate"
@@ -101,7 +102,7 @@ func TestLagrangeCanInterpolateSingleValue(t *testing.T) {
 			lg := lagrange.New()
 			lg.Fit(c.x, c.y)
 			estimate, err := interpolate.WithSingle(lg, c.valueToInterpolate)
-			assert.Equal(t, c.expectedEstimate, estimate)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
 			assert.Equal(t, c.expectedError, err)
 		})
 	}
@@ -129,8 +130,183 @@ func TestLagrangeCanInterpolateMultipleValues(t *testing.T) {
 			lg := lagrange.New()
 			lg.Fit(c.x, c.y)
 			estimates, err := interpolate.WithMulti(lg, c.valuesToInterpolate)
-			assert.Equal(t, c.expectedEstimates, estimates)
+			assert.Len(t, estimates, len(c.expectedEstimates))
+			for i := range estimates {
+				assert.InDelta(t, c.expectedEstimates[i], estimates[i], 1e-12)
+			}
 			assert.Equal(t, c.expectedError, err)
 		})
 	}
 }
+
+func TestLagrangeChebyshevNodes(t *testing.T) {
+	runge := func(x float64) float64 {
+		return 1 / (1 + 25*x*x)
+	}
+
+	cases := map[string]struct {
+		lagrange      *lagrange.Lagrange
+		x             []float64
+		expectedError error
+	}{
+		"chebyshev weights on chebyshev nodes": {
+			lagrange:      lagrange.NewChebyshev(),
+			x:             lagrange.ChebyshevNodes(41, -1, 1),
+			expectedError: nil,
+		},
+		"general weights on chebyshev nodes": {
+			lagrange:      lagrange.New(),
+			x:             lagrange.ChebyshevNodes(41, -1, 1),
+			expectedError: nil,
+		},
+		"chebyshev weights on equidistant nodes": {
+			lagrange:      lagrange.NewChebyshev(),
+			x:             []float64{-1, -0.5, 0, 0.5, 1},
+			expectedError: fmt.Errorf("Wartości X nie są węzłami Czebyszewa"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			y := make([]float64, len(c.x))
+			for i := range c.x {
+				y[i] = runge(c.x[i])
+			}
+
+			err := c.lagrange.Fit(c.x, y)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			// Interpolacja w węzłach Czebyszewa jest zbieżna dla funkcji Rungego
+			for _, val := range []float64{-0.95, -0.3, 0.123, 0.77} {
+				estimate, err := interpolate.WithSingle(c.lagrange, val)
+				assert.Nil(t, err)
+				assert.InDelta(t, runge(val), estimate, 1e-3)
+			}
+		})
+	}
+}
+
+func TestLagrangeDerivativeAndIntegral(t *testing.T) {
+	quartic := func(x float64) float64 {
+		return math.Pow(x, 4) - 3*math.Pow(x, 3) + 2*x - 5
+	}
+	antiderivative := func(x float64) float64 {
+		return math.Pow(x, 5)/5 - 3*math.Pow(x, 4)/4 + x*x - 5*x
+	}
+
+	x := []float64{-1, -0.2, 0.5, 1.1, 2, 3}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = quartic(x[i])
+	}
+
+	cases := map[string]struct {
+		order              int
+		valueToInterpolate float64
+		expectedDerivative float64
+		expectedError      error
+	}{
+		"value": {
+			order:              0,
+			valueToInterpolate: 1.7,
+			expectedDerivative: quartic(1.7),
+		},
+		"first derivative": {
+			order:              1,
+			valueToInterpolate: 1.7,
+			expectedDerivative: 4*math.Pow(1.7, 3) - 9*1.7*1.7 + 2,
+		},
+		"second derivative at node": {
+			order:              2,
+			valueToInterpolate: 0.5,
+			expectedDerivative: 12*0.5*0.5 - 18*0.5,
+		},
+		"fourth derivative": {
+			order:              4,
+			valueToInterpolate: -0.7,
+			expectedDerivative: 24,
+		},
+		"derivative above degree": {
+			order:              7,
+			valueToInterpolate: 2.5,
+			expectedDerivative: 0,
+		},
+		"negative order": {
+			order:              -1,
+			valueToInterpolate: 2.5,
+			expectedError:      fmt.Errorf("Rząd pochodnej nie może być ujemny"),
+		},
+		"too big value": {
+			order:              1,
+			valueToInterpolate: 3.5,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			lg := lagrange.New()
+			err := lg.Fit(x, y)
+			assert.Nil(t, err)
+
+			d, err := lg.Derivative(c.valueToInterpolate, c.order)
+			assert.Equal(t, c.expectedError, err)
+			assert.InDelta(t, c.expectedDerivative, d, 1e-9)
+
+			area, err := lg.Integrate(2.5, -0.5)
+			assert.Nil(t, err)
+			assert.InDelta(t, antiderivative(-0.5)-antiderivative(2.5), area, 1e-9)
+		})
+	}
+}
+
+func TestLagrangeManyNodes(t *testing.T) {
+	f := func(x float64) float64 {
+		return math.Sin(x / 10)
+	}
+
+	cases := map[string]struct {
+		x             []float64
+		expectedError error
+	}{
+		"250 chebyshev nodes on a wide interval": {
+			x:             lagrange.ChebyshevNodes(250, 0, 100),
+			expectedError: nil,
+		},
+		"2000 equidistant nodes": {
+			x: func() []float64 {
+				x := make([]float64, 2000)
+				for i := range x {
+					x[i] = float64(i)
+				}
+				return x
+			}(),
+			expectedError: fmt.Errorf("Wagi barycentryczne wykraczają poza zakres liczb zmiennoprzecinkowych dla 2000 węzłów"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			y := make([]float64, len(c.x))
+			for i := range c.x {
+				y[i] = f(c.x[i])
+			}
+
+			lg := lagrange.New()
+			err := lg.Fit(c.x, y)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			for _, val := range []float64{0.5, 33.3, 71, 99.9} {
+				estimate, err := interpolate.WithSingle(lg, val)
+				assert.Nil(t, err)
+				assert.InDelta(t, f(val), estimate, 1e-9)
+			}
+		})
+	}
+}
@@ -10,6 +10,12 @@ import (
 // Biorąc pod uwagę wycinki X i Y float64, można oszacować wartość funkcji w żądanym punkcie.
 type Linear struct {
 	interpolate.Base
+
+	pp *interpolate.Piecewise
+}
+
+func init() {
+	interpolate.Register("linear", func() interpolate.Interpolator { return New() })
 }
 
 // New zwraca nowy obiekt Linear
@@ -18,10 +24,40 @@ func New() *Linear {
 	return li
 }
 
+// Fit otrzymuje wycinki współrzędnych x i y, sortuje punkty i zapisuje interpolant jako odcinki prostych.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne.
+func (li *Linear) Fit(x, y []float64) error {
+	li.pp = nil
+
+	if err := li.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(li.XYPairs)
+	if n < 2 {
+		return nil
+	}
+
+	li.pp = &interpolate.Piecewise{Breaks: make([]float64, n), Coef: make([][]float64, n-1)}
+	for i, p := range li.XYPairs {
+		li.pp.Breaks[i] = p.X
+	}
+	for i := 0; i < n-1; i++ {
+		l, r := li.XYPairs[i], li.XYPairs[i+1]
+		li.pp.Coef[i] = []float64{l.Y, (r.Y - l.Y) / (r.X - l.X)}
+	}
+	return nil
+}
+
 func (li *Linear) Interpolate(val float64) float64 {
+	if est, ok := li.Extrapolate(val, li.Interpolate); ok {
+		return est
+	}
+
 	var est float64
 
-	l, r := li.findNearestNeighbors(val, 0, len(li.XYPairs)-1)
+	l := li.Segment(val)
+	r := l + 1
 
 	lX := li.XYPairs[l].X
 	rX := li.XYPairs[r].X
@@ -34,23 +70,35 @@ func (li *Linear) Interpolate(val float64) float64 {
 
 func (li *Linear) Validate(val float64) error {
 
-	if val < li.XYPairs[0].X {
-		return fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie")
+	if len(li.XYPairs) == 1 {
+		return fmt.Errorf("Do interpolacji liniowej potrzebne są co najmniej 2 punkty")
 	}
 
-	if val > li.XYPairs[len(li.XYPairs)-1].X {
-		return fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie")
-	}
+	return li.ValidateRange(val)
+}
 
-	return nil
+// Derivative zwraca pochodną interpolanta rzędu order w punkcie x oraz błąd (jeśli istnieje).
+// W węźle wewnętrznym zwracane jest nachylenie odcinka na prawo od węzła.
+func (li *Linear) Derivative(x float64, order int) (float64, error) {
+	if err := li.Validate(x); err != nil {
+		return 0, err
+	}
+	if li.pp == nil {
+		return 0, fmt.Errorf("Interpolant liniowy nie został dopasowany")
+	}
+	return li.DerivativeOf(x, order, li.pp.Derivative)
 }
 
-func (li *Linear) findNearestNeighbors(val float64, l, r int) (int, int) {
-	middle := (l + r) / 2
-	if (val >= li.XYPairs[middle-1].X) && (val <= li.XYPairs[middle].X) {
-		return middle - 1, middle
-	} else if val < li.XYPairs[middle-1].X {
-		return li.findNearestNeighbors(val, l, middle-2)
+// Integrate zwraca całkę oznaczoną interpolanta od from do to oraz błąd (jeśli istnieje).
+func (li *Linear) Integrate(from, to float64) (float64, error) {
+	if err := li.Validate(from); err != nil {
+		return 0, err
+	}
+	if err := li.Validate(to); err != nil {
+		return 0, err
+	}
+	if li.pp == nil {
+		return 0, fmt.Errorf("Interpolant liniowy nie został dopasowany")
 	}
-	return li.findNearestNeighbors(val, middle+1, r)
+	return li.IntegralOf(from, to, li.pp.Integrate), nil
 }
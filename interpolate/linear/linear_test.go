@@ -73,6 +73,20 @@ func TestLinearCanInterpolateSingleValue(t *testing.T) {
 			expectedEstimate:   4.655714285714286,
 			expectedError:      nil,
 		},
+		"interpolation between only two points": {
+			x:                  []float64{0, 2},
+			y:                  []float64{0, 4},
+			valueToInterpolate: 1,
+			expectedEstimate:   2,
+			expectedError:      nil,
+		},
+		"interpolation at the last point": {
+			x:                  []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 6.2},
+			y:                  []float64{3.37, 4.45, 4.81, 3.96, 3.31, 2.72, 3.02, 3.43, 4.07},
+			valueToInterpolate: 6.2,
+			expectedEstimate:   4.07,
+			expectedError:      nil,
+		},
 		"big value to interpolate test": {
 			x:                  []float64{1.8, 4.9, 2.5, 1.3, 4.4, 3.1, 3.8, 5.5, 6.2},
 			y:                  []float64{4.45, 3.02, 4.81, 3.37, 2.72, 3.96, 3.31, 3.43, 4.07},
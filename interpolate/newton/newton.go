@@ -0,0 +1,187 @@
+package newton
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/interpolate"
+)
+
+// Newton zapewnia interpolację wielomianową w postaci Newtona opartą na ilorazach różnicowych.
+// Tablica ilorazów różnicowych wyznaczana jest raz podczas Fit, a AddPoint dokłada kolejny węzeł w czasie O(n)
+// bez ponownego liczenia całej tablicy.
+type Newton struct {
+	interpolate.Base
+
+	// nodes przechowuje węzły w kolejności dodawania, w której zbudowany jest wielomian
+	nodes []float64
+	// coef przechowuje współczynniki f[x0], f[x0,x1], ..., f[x0,...,xn-1]
+	coef []float64
+	// diag przechowuje ilorazy f[xj,...,xn-1], potrzebne do dołożenia następnego węzła
+	diag []float64
+}
+
+func init() {
+	interpolate.Register("newton", func() interpolate.Interpolator { return New() })
+}
+
+// New zwraca nowy obiekt Newtona.
+// Punkty można przekazać jednorazowo przez Fit lub dokładać pojedynczo przez AddPoint.
+func New() *Newton {
+	nt := &Newton{}
+	return nt
+}
+
+// Fit otrzymuje wycinki współrzędnych x i y, sortuje punkty i buduje tablicę ilorazów różnicowych.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, brak punktów lub wartości X się powtarzają.
+func (nt *Newton) Fit(x, y []float64) error {
+	nt.nodes = nil
+	nt.coef = nil
+	nt.diag = nil
+
+	if err := nt.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(nt.XYPairs)
+	if n == 0 {
+		return fmt.Errorf("Do interpolacji Newtona potrzebny jest co najmniej 1 punkt")
+	}
+
+	// Punkty są posortowane, więc powtórzenia sąsiadują ze sobą
+	for i := 1; i < n; i++ {
+		if nt.XYPairs[i].X == nt.XYPairs[i-1].X {
+			return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X. Spowoduje to dzielenie przez zero w interpolacji Newtona")
+		}
+	}
+
+	nt.nodes = make([]float64, 0, n)
+	nt.coef = make([]float64, 0, n)
+	nt.diag = make([]float64, 0, n)
+	for _, p := range nt.XYPairs {
+		nt.extend(p.X, p.Y)
+	}
+	return nil
+}
+
+// AddPoint dokłada węzeł (x, y) do już dopasowanego interpolanta, uzupełniając tablicę ilorazów różnicowych
+// o jedną przekątną. Można ją wywołać także na nowym obiekcie bez wcześniejszego Fit - dodawanie
+// zaczyna się wtedy od pustego interpolanta.
+// Zwraca błąd, jeśli węzeł o tej wartości X już istnieje; interpolant pozostaje wtedy bez zmian.
+func (nt *Newton) AddPoint(x, y float64) error {
+	// Po nieudanym Fit w Base mogą zostać punkty, których nie ma w tablicy ilorazów
+	if len(nt.coef) == 0 {
+		nt.XYPairs, nt.X, nt.Y = nil, nil, nil
+	}
+
+	i := sort.Search(len(nt.XYPairs), func(i int) bool {
+		return nt.XYPairs[i].X >= x
+	})
+	if i < len(nt.XYPairs) && nt.XYPairs[i].X == x {
+		return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X. Spowoduje to dzielenie przez zero w interpolacji Newtona")
+	}
+
+	// Pełne wyrażenie wycinka wymusza kopię, aby nie nadpisać tablic przekazanych do Fit
+	nt.X = append(nt.X[:len(nt.X):len(nt.X)], x)
+	nt.Y = append(nt.Y[:len(nt.Y):len(nt.Y)], y)
+
+	pairs := make([]pok2.CoordinatePair, 0, len(nt.XYPairs)+1)
+	pairs = append(pairs, nt.XYPairs[:i]...)
+	pairs = append(pairs, pok2.CoordinatePair{X: x, Y: y})
+	pairs = append(pairs, nt.XYPairs[i:]...)
+	nt.XYPairs = pairs
+
+	nt.extend(x, y)
+	return nil
+}
+
+// extend dokłada węzeł na koniec tablicy ilorazów różnicowych:
+// f[xj,...,xn] = (f[xj+1,...,xn] - f[xj,...,xn-1]) / (xn - xj).
+func (nt *Newton) extend(x, y float64) {
+	n := len(nt.nodes)
+	nt.diag = append(nt.diag, y)
+	for j := n - 1; j >= 0; j-- {
+		nt.diag[j] = (nt.diag[j+1] - nt.diag[j]) / (x - nt.nodes[j])
+	}
+	nt.nodes = append(nt.nodes, x)
+	nt.coef = append(nt.coef, nt.diag[0])
+}
+
+// Coefficients zwraca kopię współczynników wielomianu w postaci Newtona względem węzłów w kolejności dodawania.
+func (nt *Newton) Coefficients() []float64 {
+	c := make([]float64, len(nt.coef))
+	copy(c, nt.coef)
+	return c
+}
+
+func (nt *Newton) Interpolate(val float64) float64 {
+	if est, ok := nt.Extrapolate(val, nt.Interpolate); ok {
+		return est
+	}
+
+	n := len(nt.coef)
+
+	// Schemat Hornera dla postaci Newtona
+	r := nt.coef[n-1]
+	for k := n - 2; k >= 0; k-- {
+		r = r*(val-nt.nodes[k]) + nt.coef[k]
+	}
+	return r
+}
+
+// derivative wyznacza pochodną wielomianu w punkcie x schematem Hornera rozszerzonym o pochodne:
+// d[j] przechowuje j-tą pochodną podzieloną przez j!.
+func (nt *Newton) derivative(x float64, order int) float64 {
+	n := len(nt.coef)
+	if order >= n {
+		return 0
+	}
+
+	d := make([]float64, order+1)
+	d[0] = nt.coef[n-1]
+	for k := n - 2; k >= 0; k-- {
+		t := x - nt.nodes[k]
+		for j := order; j >= 1; j-- {
+			d[j] = d[j]*t + d[j-1]
+		}
+		d[0] = d[0]*t + nt.coef[k]
+	}
+
+	r := d[order]
+	for j := 2; j <= order; j++ {
+		r *= float64(j)
+	}
+	return r
+}
+
+// Derivative zwraca pochodną wielomianu interpolacyjnego rzędu order w punkcie x oraz błąd (jeśli istnieje).
+func (nt *Newton) Derivative(x float64, order int) (float64, error) {
+	if err := nt.Validate(x); err != nil {
+		return 0, err
+	}
+	return nt.DerivativeOf(x, order, nt.derivative)
+}
+
+// Integrate zwraca całkę oznaczoną wielomianu interpolacyjnego od from do to oraz błąd (jeśli istnieje).
+// Całka liczona jest kwadraturą Gaussa-Legendre'a, dokładną dla wielomianu stopnia n-1.
+func (nt *Newton) Integrate(from, to float64) (float64, error) {
+	if err := nt.Validate(from); err != nil {
+		return 0, err
+	}
+	if err := nt.Validate(to); err != nil {
+		return 0, err
+	}
+	return nt.IntegralOf(from, to, func(lo, hi float64) float64 {
+		return interpolate.GaussLegendre(nt.Interpolate, lo, hi, len(nt.coef)/2+1)
+	}), nil
+}
+
+func (nt *Newton) Validate(val float64) error {
+
+	if len(nt.coef) == 0 {
+		return fmt.Errorf("Interpolant Newtona nie został dopasowany")
+	}
+
+	return nt.ValidateRange(val)
+}
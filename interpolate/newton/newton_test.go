@@ -0,0 +1,261 @@
+package newton_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/lagrange"
+	"github.com/53jk1/pok2/interpolate/newton"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewtonCanFit(t *testing.T) {
+	cases := map[string]struct {
+		x             []float64
+		y             []float64
+		expectedError error
+	}{
+		"basic newton fit": {
+			x:             []float64{1.3, 1.8, 2.5, 3.1, 3.8},
+			y:             []float64{3.37, 4.45, 4.81, 3.96, 3.31},
+			expectedError: nil,
+		},
+		"wrong x and y size": {
+			x:             []float64{1.3, 1.8, 2.5},
+			y:             []float64{3.37, 4.45},
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"no points": {
+			x:             []float64{},
+			y:             []float64{},
+			expectedError: fmt.Errorf("Do interpolacji Newtona potrzebny jest co najmniej 1 punkt"),
+		},
+		"same x values": {
+			x:             []float64{1.3, 1.8, 1.3},
+			y:             []float64{3.37, 4.45, 4.81},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same wartości X. Spowoduje to dzielenie przez zero w interpolacji Newtona"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			nt := newton.New()
+			err := nt.Fit(c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestNewtonCanInterpolateSingleValue(t *testing.T) {
+	cases := map[string]struct {
+		x                  []float64
+		y                  []float64
+		valueToInterpolate float64
+		expectedEstimate   float64
+		expectedError      error
+	}{
+		"newton reproduces a cubic": {
+			x:                  []float64{-1, 0, 1, 2},
+			y:                  []float64{-2, 1, 2, 7},
+			valueToInterpolate: 0.5,
+			expectedEstimate:   1.375,
+			expectedError:      nil,
+		},
+		"unsorted points": {
+			x:                  []float64{2, -1, 1, 0},
+			y:                  []float64{7, -2, 2, 1},
+			valueToInterpolate: 1.5,
+			expectedEstimate:   3.625,
+			expectedError:      nil,
+		},
+		"single point": {
+			x:                  []float64{3},
+			y:                  []float64{5},
+			valueToInterpolate: 3,
+			expectedEstimate:   5,
+			expectedError:      nil,
+		},
+		"too small value to interpolate": {
+			x:                  []float64{-1, 0, 1, 2},
+			y:                  []float64{-2, 1, 2, 7},
+			valueToInterpolate: -1.5,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+		"too big value to interpolate": {
+			x:                  []float64{-1, 0, 1, 2},
+			y:                  []float64{-2, 1, 2, 7},
+			valueToInterpolate: 2.5,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			nt := newton.New()
+			err := nt.Fit(c.x, c.y)
+			assert.Nil(t, err)
+
+			estimate, err := interpolate.WithSingle(nt, c.valueToInterpolate)
+			assert.Equal(t, c.expectedError, err)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
+		})
+	}
+}
+
+func TestNewtonAddPoint(t *testing.T) {
+	cases := map[string]struct {
+		fitX          []float64
+		fitY          []float64
+		addX          []float64
+		addY          []float64
+		expectedError error
+	}{
+		"add points after fit": {
+			fitX:          []float64{1.3, 2.5, 3.8},
+			fitY:          []float64{3.37, 4.81, 3.31},
+			addX:          []float64{1.8, 4.4, 3.1, 1.0},
+			addY:          []float64{4.45, 2.72, 3.96, 2.9},
+			expectedError: nil,
+		},
+		"stream points without fit": {
+			addX:          []float64{1.3, 2.5, 3.8, 1.8, 4.4},
+			addY:          []float64{3.37, 4.81, 3.31, 4.45, 2.72},
+			expectedError: nil,
+		},
+		"duplicate x value": {
+			fitX:          []float64{1.3, 2.5, 3.8},
+			fitY:          []float64{3.37, 4.81, 3.31},
+			addX:          []float64{2.5},
+			addY:          []float64{1},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same wartości X. Spowoduje to dzielenie przez zero w interpolacji Newtona"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			nt := newton.New()
+			if c.fitX != nil {
+				assert.Nil(t, nt.Fit(c.fitX, c.fitY))
+			}
+
+			var err error
+			for i := range c.addX {
+				if err = nt.AddPoint(c.addX[i], c.addY[i]); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, c.expectedError, err)
+
+			// Interpolant po dodaniu punktów musi być tym samym wielomianem co dopasowany od zera
+			x, y := append([]float64{}, c.fitX...), append([]float64{}, c.fitY...)
+			if err == nil {
+				x, y = append(x, c.addX...), append(y, c.addY...)
+			}
+			assert.Equal(t, len(x), len(nt.XYPairs))
+
+			lg := lagrange.New()
+			assert.Nil(t, lg.Fit(x, y))
+			for i := 0; i <= 10; i++ {
+				val := nt.XYPairs[0].X + float64(i)*(nt.XYPairs[len(nt.XYPairs)-1].X-nt.XYPairs[0].X)/10
+				expected, err := interpolate.WithSingle(lg, val)
+				assert.Nil(t, err)
+				estimate, err := interpolate.WithSingle(nt, val)
+				assert.Nil(t, err)
+				assert.InDelta(t, expected, estimate, 1e-9)
+			}
+		})
+	}
+}
+
+func TestNewtonAddPointDoesNotModifyInput(t *testing.T) {
+	x := make([]float64, 3, 4)
+	copy(x, []float64{1, 2, 3})
+	y := make([]float64, 3, 4)
+	copy(y, []float64{1, 4, 9})
+
+	nt := newton.New()
+	assert.Nil(t, nt.Fit(x, y))
+	assert.Nil(t, nt.AddPoint(4, 16))
+
+	assert.Equal(t, []float64{1, 2, 3}, x)
+	assert.Equal(t, []float64{1, 2, 3, 0}, x[:4])
+	assert.Equal(t, []float64{1, 4, 9, 0}, y[:4])
+}
+
+func TestNewtonDerivativeAndIntegral(t *testing.T) {
+	quartic := func(x float64) float64 {
+		return math.Pow(x, 4) - 3*math.Pow(x, 3) + 2*x - 5
+	}
+	antiderivative := func(x float64) float64 {
+		return math.Pow(x, 5)/5 - 3*math.Pow(x, 4)/4 + x*x - 5*x
+	}
+
+	x := []float64{-1, -0.2, 0.5, 1.1, 2, 3}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = quartic(x[i])
+	}
+
+	cases := map[string]struct {
+		order              int
+		valueToInterpolate float64
+		expectedDerivative float64
+		expectedError      error
+	}{
+		"value": {
+			order:              0,
+			valueToInterpolate: 1.7,
+			expectedDerivative: quartic(1.7),
+		},
+		"first derivative": {
+			order:              1,
+			valueToInterpolate: 1.7,
+			expectedDerivative: 4*math.Pow(1.7, 3) - 9*1.7*1.7 + 2,
+		},
+		"second derivative at node": {
+			order:              2,
+			valueToInterpolate: 0.5,
+			expectedDerivative: 12*0.5*0.5 - 18*0.5,
+		},
+		"fourth derivative": {
+			order:              4,
+			valueToInterpolate: -0.7,
+			expectedDerivative: 24,
+		},
+		"derivative above degree": {
+			order:              7,
+			valueToInterpolate: 2.5,
+			expectedDerivative: 0,
+		},
+		"negative order": {
+			order:              -1,
+			valueToInterpolate: 2.5,
+			expectedError:      fmt.Errorf("Rząd pochodnej nie może być ujemny"),
+		},
+		"too big value": {
+			order:              1,
+			valueToInterpolate: 3.5,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			nt := newton.New()
+			err := nt.Fit(x, y)
+			assert.Nil(t, err)
+
+			d, err := nt.Derivative(c.valueToInterpolate, c.order)
+			assert.Equal(t, c.expectedError, err)
+			assert.InDelta(t, c.expectedDerivative, d, 1e-9)
+
+			area, err := nt.Integrate(2.5, -0.5)
+			assert.Nil(t, err)
+			assert.InDelta(t, antiderivative(-0.5)-antiderivative(2.5), area, 1e-9)
+		})
+	}
+}
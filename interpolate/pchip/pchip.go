@@ -0,0 +1,134 @@
+package pchip
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/53jk1/pok2/interpolate"
+)
+
+// Pchip zapewnia monotoniczną interpolację sześcienną Hermite'a (PCHIP) metodą Fritscha-Carlsona.
+// Na przedziałach, na których dane są monotoniczne, interpolant również jest monotoniczny i nie przekracza wartości w węzłach.
+type Pchip struct {
+	interpolate.Base
+
+	// pp to interpolant zapisany jako wielomiany Hermite'a na kolejnych przedziałach XYPairs
+	pp *interpolate.Piecewise
+}
+
+func init() {
+	interpolate.Register("pchip", func() interpolate.Interpolator { return New() })
+}
+
+// New zwraca nowy obiekt Pchip.
+func New() *Pchip {
+	p := &Pchip{}
+	return p
+}
+
+// Fit otrzymuje wycinki współrzędnych x i y, sortuje punkty i wyznacza pochodne w węzłach.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, punktów jest mniej niż dwa lub wartości X się powtarzają.
+func (p *Pchip) Fit(x, y []float64) error {
+	p.pp = nil
+
+	if err := p.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(p.XYPairs)
+	if n < 2 {
+		return fmt.Errorf("Do interpolacji PCHIP potrzebne są co najmniej 2 punkty")
+	}
+
+	h := make([]float64, n-1)
+	delta := make([]float64, n-1)
+	for i := range h {
+		h[i] = p.XYPairs[i+1].X - p.XYPairs[i].X
+		if h[i] == 0 {
+			return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X")
+		}
+		delta[i] = (p.XYPairs[i+1].Y - p.XYPairs[i].Y) / h[i]
+	}
+
+	d := make([]float64, n)
+	if n == 2 {
+		d[0], d[1] = delta[0], delta[0]
+		p.pp = interpolate.NewHermite(p.XYPairs, d)
+		return nil
+	}
+
+	for i := 1; i < n-1; i++ {
+		// Zmiana kierunku lub płaski odcinek oznacza ekstremum lokalne w węźle
+		if delta[i-1]*delta[i] <= 0 {
+			continue
+		}
+		w1 := 2*h[i] + h[i-1]
+		w2 := h[i] + 2*h[i-1]
+		d[i] = (w1 + w2) / (w1/delta[i-1] + w2/delta[i])
+	}
+
+	d[0] = endSlope(h[0], h[1], delta[0], delta[1])
+	d[n-1] = endSlope(h[n-2], h[n-3], delta[n-2], delta[n-3])
+
+	p.pp = interpolate.NewHermite(p.XYPairs, d)
+	return nil
+}
+
+// endSlope wyznacza pochodną na końcu przedziału z trzypunktowego wzoru niesymetrycznego,
+// ograniczając ją tak, aby zachować monotoniczność.
+func endSlope(h0, h1, delta0, delta1 float64) float64 {
+	d := ((2*h0+h1)*delta0 - h0*delta1) / (h0 + h1)
+
+	if sign(d) != sign(delta0) {
+		return 0
+	}
+	if sign(delta0) != sign(delta1) && math.Abs(d) > 3*math.Abs(delta0) {
+		return 3 * delta0
+	}
+	return d
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func (p *Pchip) Interpolate(val float64) float64 {
+	if est, ok := p.Extrapolate(val, p.Interpolate); ok {
+		return est
+	}
+	return p.pp.Eval(val)
+}
+
+func (p *Pchip) Validate(val float64) error {
+
+	if p.pp == nil {
+		return fmt.Errorf("Interpolant PCHIP nie został dopasowany")
+	}
+
+	return p.ValidateRange(val)
+}
+
+// Derivative zwraca pochodną interpolanta rzędu order w punkcie x oraz błąd (jeśli istnieje).
+func (p *Pchip) Derivative(x float64, order int) (float64, error) {
+	if err := p.Validate(x); err != nil {
+		return 0, err
+	}
+	return p.DerivativeOf(x, order, p.pp.Derivative)
+}
+
+// Integrate zwraca całkę oznaczoną interpolanta od from do to oraz błąd (jeśli istnieje).
+func (p *Pchip) Integrate(from, to float64) (float64, error) {
+	if err := p.Validate(from); err != nil {
+		return 0, err
+	}
+	if err := p.Validate(to); err != nil {
+		return 0, err
+	}
+	return p.IntegralOf(from, to, p.pp.Integrate), nil
+}
@@ -0,0 +1,127 @@
+package pchip_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/pchip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPchipCanFit(t *testing.T) {
+	cases := map[string]struct {
+		x             []float64
+		y             []float64
+		expectedError error
+	}{
+		"basic pchip fit": {
+			x:             []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 6.2},
+			y:             []float64{3.37, 4.45, 4.81, 3.96, 3.31, 2.72, 3.02, 3.43, 4.07},
+			expectedError: nil,
+		},
+		"wrong x and y size": {
+			x:             []float64{1.3, 1.8, 2.5},
+			y:             []float64{3.37, 4.45},
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"single point": {
+			x:             []float64{1.3},
+			y:             []float64{3.37},
+			expectedError: fmt.Errorf("Do interpolacji PCHIP potrzebne są co najmniej 2 punkty"),
+		},
+		"same x values": {
+			x:             []float64{1.3, 1.8, 1.8},
+			y:             []float64{3.37, 4.45, 4.81},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same wartości X"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := pchip.New()
+			err := p.Fit(c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestPchipCanInterpolateSingleValue(t *testing.T) {
+	cases := map[string]struct {
+		x                  []float64
+		y                  []float64
+		valueToInterpolate float64
+		expectedEstimate   float64
+		expectedError      error
+	}{
+		"pchip reproduces a line": {
+			x:                  []float64{0, 1, 3, 4},
+			y:                  []float64{1, 3, 7, 9},
+			valueToInterpolate: 2.2,
+			expectedEstimate:   5.4,
+			expectedError:      nil,
+		},
+		"pchip between two points": {
+			x:                  []float64{0, 2},
+			y:                  []float64{1, 5},
+			valueToInterpolate: 0.5,
+			expectedEstimate:   2,
+			expectedError:      nil,
+		},
+		"pchip flat at a local extremum": {
+			x:                  []float64{0, 1, 2},
+			y:                  []float64{0, 1, 0},
+			valueToInterpolate: 0.5,
+			expectedEstimate:   0.75,
+			expectedError:      nil,
+		},
+		"big value to interpolate test": {
+			x:                  []float64{1.8, 4.9, 2.5, 1.3},
+			y:                  []float64{4.45, 3.02, 4.81, 3.37},
+			valueToInterpolate: 1000,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+		"too small value to interpolate test": {
+			x:                  []float64{1.8, 4.9, 2.5, 1.3},
+			y:                  []float64{4.45, 3.02, 4.81, 3.37},
+			valueToInterpolate: -20,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := pchip.New()
+			p.Fit(c.x, c.y)
+			estimate, err := interpolate.WithSingle(p, c.valueToInterpolate)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestPchipIsMonotone(t *testing.T) {
+	// Krzywa skumulowana z ostrym skokiem, na której splajn sześcienny wychodzi poza zakres danych
+	x := []float64{0, 1, 2, 3, 4, 5, 6}
+	y := []float64{0, 0.1, 0.2, 5, 9.8, 9.9, 10}
+
+	p := pchip.New()
+	assert.Nil(t, p.Fit(x, y))
+
+	var vals []float64
+	for v := 0.0; v <= 6; v += 0.01 {
+		vals = append(vals, v)
+	}
+	estimates, err := interpolate.WithMulti(p, vals)
+	assert.Nil(t, err)
+
+	for i := 1; i < len(estimates); i++ {
+		assert.Equal(t, true, estimates[i] >= estimates[i-1], "not monotone at %v", vals[i])
+	}
+	for i := range x {
+		est, _ := interpolate.WithSingle(p, x[i])
+		assert.InDelta(t, y[i], est, 1e-12)
+	}
+}
@@ -0,0 +1,221 @@
+package rbf
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/53jk1/pok2"
+)
+
+// Kernel określa radialną funkcję bazową phi(r)
+type Kernel int
+
+const (
+	// Gaussian to phi(r) = exp(-(eps*r)^2)
+	Gaussian Kernel = iota
+	// Multiquadric to phi(r) = sqrt(1 + (eps*r)^2)
+	Multiquadric
+	// InverseMultiquadric to phi(r) = 1 / sqrt(1 + (eps*r)^2)
+	InverseMultiquadric
+	// ThinPlate to phi(r) = r^2 * log(r), uzupełniona wielomianem liniowym
+	ThinPlate
+)
+
+// RBF zapewnia interpolację danych rozproszonych w N wymiarach radialnymi funkcjami bazowymi.
+// Oszacowanie ma postać s(p) = sum(w[i] * phi(|p - P[i]|)), a dla ThinPlate dodatkowo c0 + c1*p1 + ... + cN*pN.
+// Funkcja bazowa ustalana jest w konstruktorze, ponieważ wagi wyznaczone w Fit są dla niej specyficzne.
+type RBF struct {
+	// Epsilon to parametr kształtu; wartość 0 oznacza dobór automatyczny
+	// jako odwrotność średniej odległości punktu od najbliższego sąsiada. ThinPlate go nie używa.
+	// Epsilon odczytywany jest w Fit, więc jego zmiana wymaga ponownego dopasowania.
+	Epsilon float64
+
+	kernel  Kernel
+	points  pok2.Matrix
+	weights pok2.Vector
+	poly    pok2.Vector
+	eps     float64
+}
+
+// New zwraca nowy obiekt RBF z podaną funkcją bazową.
+func New(kernel Kernel) *RBF {
+	r := &RBF{kernel: kernel}
+	return r
+}
+
+// Fit otrzymuje macierz punktów, w której każdy wiersz to współrzędne jednego punktu, oraz wartości w tych punktach.
+// Wagi wyznaczane są z układu równań rozwiązywanego rozkładem LU.
+// Zwraca błąd, jeśli macierz punktów jest pusta lub poszarpana, liczba wartości nie zgadza się z liczbą punktów,
+// punkty się powtarzają lub układ jest osobliwy.
+func (r *RBF) Fit(points pok2.Matrix, values []float64) error {
+	r.points, r.weights, r.poly = nil, nil, nil
+
+	if err := points.Validate(); err != nil {
+		return err
+	}
+
+	n, d := points.Dim()
+	if n != len(values) {
+		return fmt.Errorf("Liczba punktów (%d) nie pasuje do liczby wartości (%d)", n, len(values))
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if distance(points[i], points[j]) == 0 {
+				return fmt.Errorf("Istnieją co najmniej 2 takie same punkty")
+			}
+		}
+	}
+
+	r.eps = r.Epsilon
+	if r.eps == 0 {
+		r.eps = shape(points)
+	}
+
+	// Dla ThinPlate układ jest rozszerzony o wielomian liniowy i warunki sum(w[i]) = 0, sum(w[i]*P[i]) = 0
+	size := n
+	if r.kernel == ThinPlate {
+		size += d + 1
+	}
+
+	a := make(pok2.Matrix, size)
+	b := make(pok2.Matrix, size)
+	for i := range a {
+		a[i] = make(pok2.Vector, size)
+		b[i] = pok2.Vector{0}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a[i][j] = r.phi(distance(points[i], points[j]))
+		}
+		b[i][0] = values[i]
+	}
+	if r.kernel == ThinPlate {
+		for i := 0; i < n; i++ {
+			a[i][n], a[n][i] = 1, 1
+			for k := 0; k < d; k++ {
+				a[i][n+1+k], a[n+1+k][i] = points[i][k], points[i][k]
+			}
+		}
+	}
+
+	lu, err := a.LU()
+	if err != nil {
+		return err
+	}
+	x, err := lu.Solve(b)
+	if err != nil {
+		return err
+	}
+
+	r.points = make(pok2.Matrix, n)
+	for i := range points {
+		r.points[i] = append(pok2.Vector{}, points[i]...)
+	}
+	r.weights = make(pok2.Vector, n)
+	for i := range r.weights {
+		r.weights[i] = x[i][0]
+	}
+	if r.kernel == ThinPlate {
+		r.poly = make(pok2.Vector, d+1)
+		for k := range r.poly {
+			r.poly[k] = x[n+k][0]
+		}
+	}
+	return nil
+}
+
+// shape zwraca odwrotność średniej odległości punktu od najbliższego sąsiada
+func shape(points pok2.Matrix) float64 {
+	if len(points) < 2 {
+		return 1
+	}
+
+	var sum float64
+	for i := range points {
+		nearest := math.Inf(1)
+		for j := range points {
+			if i != j {
+				nearest = math.Min(nearest, distance(points[i], points[j]))
+			}
+		}
+		sum += nearest
+	}
+	return float64(len(points)) / sum
+}
+
+func distance(p, q pok2.Vector) float64 {
+	var sum float64
+	for k := range p {
+		sum += (p[k] - q[k]) * (p[k] - q[k])
+	}
+	return math.Sqrt(sum)
+}
+
+func (r *RBF) phi(dist float64) float64 {
+	switch r.kernel {
+	case Multiquadric:
+		return math.Sqrt(1 + (r.eps*dist)*(r.eps*dist))
+	case InverseMultiquadric:
+		return 1 / math.Sqrt(1+(r.eps*dist)*(r.eps*dist))
+	case ThinPlate:
+		if dist == 0 {
+			return 0
+		}
+		return dist * dist * math.Log(dist)
+	}
+	return math.Exp(-(r.eps * dist) * (r.eps * dist))
+}
+
+func (r *RBF) Interpolate(p pok2.Vector) float64 {
+	var est float64
+	for i := range r.points {
+		est += r.weights[i] * r.phi(distance(p, r.points[i]))
+	}
+	if r.poly != nil {
+		est += r.poly[0]
+		for k := range p {
+			est += r.poly[k+1] * p[k]
+		}
+	}
+	return est
+}
+
+func (r *RBF) Validate(p pok2.Vector) error {
+
+	if r.weights == nil {
+		return fmt.Errorf("Interpolant RBF nie został dopasowany")
+	}
+
+	if len(p) != len(r.points[0]) {
+		return fmt.Errorf("Wymiar punktu (%d) nie pasuje do wymiaru danych (%d)", len(p), len(r.points[0]))
+	}
+
+	return nil
+}
+
+// WithSingle zwraca interpolowaną wartość w punkcie p oraz błąd
+func WithSingle(r *RBF, p pok2.Vector) (float64, error) {
+	var est float64
+
+	err := r.Validate(p)
+	if err != nil {
+		return est, err
+	}
+
+	est = r.Interpolate(p)
+	return est, nil
+}
+
+// WithMulti zwraca interpolowane wartości w punktach będących wierszami macierzy oraz błąd
+func WithMulti(r *RBF, points pok2.Matrix) ([]float64, error) {
+	var est []float64
+	for _, p := range points {
+		e, err := WithSingle(r, p)
+		if err != nil {
+			return est, err
+		}
+		est = append(est, e)
+	}
+	return est, nil
+}
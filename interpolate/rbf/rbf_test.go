@@ -0,0 +1,130 @@
+package rbf_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/interpolate/rbf"
+	"github.com/stretchr/testify/assert"
+)
+
+// sensors to nieregularnie rozmieszczone punkty na płaszczyźnie
+var sensors = pok2.Matrix{
+	{0.1, 0.2}, {0.9, 0.1}, {0.5, 0.5}, {0.2, 0.8}, {0.8, 0.9},
+	{0.4, 0.1}, {0.6, 0.7}, {0.05, 0.5}, {0.95, 0.55}, {0.35, 0.35},
+}
+
+func TestRBFCanFit(t *testing.T) {
+	cases := map[string]struct {
+		points        pok2.Matrix
+		values        []float64
+		expectedError error
+	}{
+		"basic rbf fit": {
+			points:        sensors,
+			values:        make([]float64, len(sensors)),
+			expectedError: nil,
+		},
+		"empty points": {
+			points:        pok2.Matrix{},
+			values:        []float64{},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"ragged points": {
+			points:        pok2.Matrix{{0, 0}, {1}},
+			values:        []float64{1, 2},
+			expectedError: &pok2.RaggedError{Row: 1, Len: 1, Cols: 2},
+		},
+		"wrong number of values": {
+			points:        pok2.Matrix{{0, 0}, {1, 1}},
+			values:        []float64{1},
+			expectedError: fmt.Errorf("Liczba punktów (2) nie pasuje do liczby wartości (1)"),
+		},
+		"same points": {
+			points:        pok2.Matrix{{0, 0}, {1, 1}, {0, 0}},
+			values:        []float64{1, 2, 3},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same punkty"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := rbf.New(rbf.Gaussian)
+			err := r.Fit(c.points, c.values)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestRBFReproducesDataPoints(t *testing.T) {
+	f := func(p pok2.Vector) float64 {
+		return math.Sin(3*p[0]) + p[1]*p[1]
+	}
+	values := make([]float64, len(sensors))
+	for i := range sensors {
+		values[i] = f(sensors[i])
+	}
+
+	cases := map[string]struct {
+		kernel  rbf.Kernel
+		epsilon float64
+	}{
+		"gaussian":             {kernel: rbf.Gaussian, epsilon: 3},
+		"multiquadric":         {kernel: rbf.Multiquadric},
+		"inverse multiquadric": {kernel: rbf.InverseMultiquadric},
+		"thin plate":           {kernel: rbf.ThinPlate},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := rbf.New(c.kernel)
+			r.Epsilon = c.epsilon
+			err := r.Fit(sensors, values)
+			assert.Nil(t, err)
+
+			estimates, err := rbf.WithMulti(r, sensors)
+			assert.Nil(t, err)
+			for i := range values {
+				assert.InDelta(t, values[i], estimates[i], 1e-8)
+			}
+
+			// Między czujnikami oszacowanie powinno być bliskie gładkiej funkcji
+			estimate, err := rbf.WithSingle(r, pok2.Vector{0.45, 0.45})
+			assert.Nil(t, err)
+			assert.InDelta(t, f(pok2.Vector{0.45, 0.45}), estimate, 0.05)
+		})
+	}
+}
+
+func TestRBFThinPlateReproducesLinearFunction(t *testing.T) {
+	points := pok2.Matrix{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}, {0.3, 0.6, 0.2}}
+	linear := func(p pok2.Vector) float64 {
+		return 2 - p[0] + 3*p[1] + 0.5*p[2]
+	}
+	values := make([]float64, len(points))
+	for i := range points {
+		values[i] = linear(points[i])
+	}
+
+	r := rbf.New(rbf.ThinPlate)
+	err := r.Fit(points, values)
+	assert.Nil(t, err)
+
+	for _, p := range []pok2.Vector{{0.5, 0.5, 0.5}, {0.1, 0.9, 0.3}, {2, -1, 0}} {
+		estimate, err := rbf.WithSingle(r, p)
+		assert.Nil(t, err)
+		assert.InDelta(t, linear(p), estimate, 1e-9)
+	}
+}
+
+func TestRBFValidate(t *testing.T) {
+	r := rbf.New(rbf.Multiquadric)
+	assert.Equal(t, fmt.Errorf("Interpolant RBF nie został dopasowany"), r.Validate(pok2.Vector{0, 0}))
+
+	err := r.Fit(pok2.Matrix{{0, 0}, {1, 1}}, []float64{1, 2})
+	assert.Nil(t, err)
+	_, err = rbf.WithSingle(r, pok2.Vector{0, 0, 0})
+	assert.Equal(t, fmt.Errorf("Wymiar punktu (3) nie pasuje do wymiaru danych (2)"), err)
+}
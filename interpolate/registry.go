@@ -0,0 +1,57 @@
+package interpolate
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]func() Interpolator)
+)
+
+// Register udostępnia metodę interpolacji pod podaną nazwą dla New.
+// Pakiety z tego modułu rejestrują się same w funkcji init, więc wystarczy je zaimportować, np.
+//
+//	import _ "github.com/53jk1/pok2/interpolate/linear"
+//
+// Register panikuje, jeśli fabryka jest nil lub nazwa jest już zajęta.
+func Register(name string, factory func() Interpolator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("interpolate: fabryka dla metody " + name + " jest nil")
+	}
+	if _, ok := registry[name]; ok {
+		panic("interpolate: metoda " + name + " jest już zarejestrowana")
+	}
+	registry[name] = factory
+}
+
+// New zwraca nowy, niedopasowany obiekt metody interpolacji zarejestrowanej pod podaną nazwą.
+// Zwraca błąd, jeśli żadna metoda nie jest zarejestrowana pod tą nazwą.
+func New(name string) (Interpolator, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("Nieznana metoda interpolacji: %q", name)
+	}
+	return factory(), nil
+}
+
+// Methods zwraca posortowane nazwy zarejestrowanych metod interpolacji.
+func Methods() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
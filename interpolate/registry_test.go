@@ -0,0 +1,105 @@
+package interpolate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/stretchr/testify/assert"
+)
+
+// nearest to przykładowa metoda spoza modułu, najbliższy sąsiad bez walidacji zakresu
+type nearest struct {
+	interpolate.Base
+}
+
+func (n *nearest) Interpolate(val float64) float64 {
+	i := n.Segment(val)
+	if val-n.XYPairs[i].X > n.XYPairs[i+1].X-val {
+		i++
+	}
+	return n.XYPairs[i].Y
+}
+
+func (n *nearest) Validate(float64) error {
+	return nil
+}
+
+func TestRegistryNew(t *testing.T) {
+	cases := map[string]struct {
+		method           string
+		expectedEstimate float64
+		expectedError    error
+	}{
+		"linear": {
+			method:           "linear",
+			expectedEstimate: 6.5,
+		},
+		"newton": {
+			method:           "newton",
+			expectedEstimate: 6.25,
+		},
+		"spline": {
+			method:           "spline-not-a-knot",
+			expectedEstimate: 6.25,
+		},
+		"unknown method": {
+			method:        "cubic",
+			expectedError: fmt.Errorf("Nieznana metoda interpolacji: %q", "cubic"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			in, err := interpolate.New(c.method)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				assert.Nil(t, in)
+				return
+			}
+
+			err = in.Fit([]float64{0, 1, 2, 3}, []float64{0, 1, 4, 9})
+			assert.Nil(t, err)
+
+			estimate, err := interpolate.WithSingle(in, 2.5)
+			assert.Nil(t, err)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-12)
+		})
+	}
+}
+
+func TestRegistryNewReturnsFreshObjects(t *testing.T) {
+	a, err := interpolate.New("linear")
+	assert.Nil(t, err)
+	b, err := interpolate.New("linear")
+	assert.Nil(t, err)
+
+	assert.Nil(t, a.Fit([]float64{0, 1}, []float64{0, 1}))
+	assert.Nil(t, b.Fit([]float64{0, 1}, []float64{0, 2}))
+	assert.Equal(t, 0.5, a.Interpolate(0.5))
+	assert.Equal(t, 1.0, b.Interpolate(0.5))
+}
+
+func TestRegister(t *testing.T) {
+	interpolate.Register("test-nearest", func() interpolate.Interpolator { return &nearest{} })
+
+	in, err := interpolate.New("test-nearest")
+	assert.Nil(t, err)
+	assert.Nil(t, in.Fit([]float64{0, 1, 2}, []float64{5, 6, 7}))
+
+	estimates, err := interpolate.WithMulti(in, []float64{0.4, 0.6, 1.9})
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{5, 6, 7}, estimates)
+
+	assert.Contains(t, interpolate.Methods(), "test-nearest")
+	for _, name := range []string{"akima", "lagrange", "lagrange-chebyshev", "linear", "newton", "pchip", "spline", "spline-not-a-knot"} {
+		assert.Contains(t, interpolate.Methods(), name)
+	}
+
+	assert.Panics(t, func() {
+		interpolate.Register("test-nearest", func() interpolate.Interpolator { return &nearest{} })
+	})
+	assert.Panics(t, func() {
+		interpolate.Register("test-nil", nil)
+	})
+}
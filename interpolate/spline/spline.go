@@ -0,0 +1,212 @@
+package spline
+
+import (
+	"fmt"
+
+	"github.com/53jk1/pok2/interpolate"
+)
+
+// Boundary określa warunek brzegowy splajnu sześciennego.
+type Boundary int
+
+const (
+	// Natural zeruje drugą pochodną na obu końcach przedziału.
+	Natural Boundary = iota
+	// Clamped ustala pierwszą pochodną na końcach przedziału na LeftSlope i RightSlope.
+	Clamped
+	// NotAKnot wymusza ciągłość trzeciej pochodnej w drugim i przedostatnim węźle.
+	NotAKnot
+)
+
+// Spline zapewnia interpolację splajnem sześciennym z wybranym warunkiem brzegowym.
+// Biorąc pod uwagę wycinki X i Y float64, można oszacować wartość funkcji w żądanym punkcie.
+type Spline struct {
+	interpolate.Base
+	Boundary   Boundary
+	LeftSlope  float64
+	RightSlope float64
+
+	// pp to splajn zapisany jako wielomiany sześcienne na kolejnych przedziałach XYPairs
+	pp *interpolate.Piecewise
+}
+
+func init() {
+	interpolate.Register("spline", func() interpolate.Interpolator { return New() })
+	interpolate.Register("spline-not-a-knot", func() interpolate.Interpolator { return NewNotAKnot() })
+}
+
+// New zwraca nowy obiekt Spline z naturalnym warunkiem brzegowym.
+func New() *Spline {
+	s := &Spline{Boundary: Natural}
+	return s
+}
+
+// NewClamped zwraca nowy obiekt Spline z zadanymi pierwszymi pochodnymi na lewym i prawym końcu.
+func NewClamped(leftSlope, rightSlope float64) *Spline {
+	s := &Spline{Boundary: Clamped, LeftSlope: leftSlope, RightSlope: rightSlope}
+	return s
+}
+
+// NewNotAKnot zwraca nowy obiekt Spline z warunkiem brzegowym "not-a-knot".
+func NewNotAKnot() *Spline {
+	s := &Spline{Boundary: NotAKnot}
+	return s
+}
+
+// Fit otrzymuje wycinki współrzędnych x i y, sortuje punkty i wyznacza drugie pochodne splajnu w węzłach.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, punktów jest mniej niż dwa lub wartości X się powtarzają.
+func (s *Spline) Fit(x, y []float64) error {
+	s.pp = nil
+
+	if err := s.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(s.XYPairs)
+	if n < 2 {
+		return fmt.Errorf("Do interpolacji splajnem potrzebne są co najmniej 2 punkty")
+	}
+
+	h := make([]float64, n-1)
+	for i := range h {
+		h[i] = s.XYPairs[i+1].X - s.XYPairs[i].X
+		if h[i] == 0 {
+			return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X")
+		}
+	}
+
+	// Układ trójprzekątniowy: sub[i]*m[i-1] + diag[i]*m[i] + sup[i]*m[i+1] = rhs[i]
+	sub := make([]float64, n)
+	diag := make([]float64, n)
+	sup := make([]float64, n)
+	rhs := make([]float64, n)
+
+	slope := func(i int) float64 {
+		return (s.XYPairs[i+1].Y - s.XYPairs[i].Y) / h[i]
+	}
+
+	for i := 1; i < n-1; i++ {
+		sub[i] = h[i-1]
+		diag[i] = 2 * (h[i-1] + h[i])
+		sup[i] = h[i]
+		rhs[i] = 6 * (slope(i) - slope(i-1))
+	}
+
+	switch {
+	case s.Boundary == Clamped:
+		diag[0] = 2 * h[0]
+		sup[0] = h[0]
+		rhs[0] = 6 * (slope(0) - s.LeftSlope)
+		sub[n-1] = h[n-2]
+		diag[n-1] = 2 * h[n-2]
+		rhs[n-1] = 6 * (s.RightSlope - slope(n-2))
+	case s.Boundary == NotAKnot && n == 3:
+		// Oba warunki dotyczą jedynego węzła wewnętrznego, więc splajn jest parabolą: m0 = m1 = m2
+		diag[0] = 1
+		sup[0] = -1
+		sub[n-1] = -1
+		diag[n-1] = 1
+	case s.Boundary == NotAKnot && n >= 4:
+		// h1*m0 - (h0+h1)*m1 + h0*m2 = 0; wyznaczamy z niego m0 i podstawiamy do równania dla węzła 1.
+		// Eliminacja w drugą stronę daje zerowy element na przekątnej dla równych odstępów h0 = h1.
+		diag[1] += sub[1] * (h[0] + h[1]) / h[1]
+		sup[1] -= sub[1] * h[0] / h[1]
+
+		// analogicznie na prawym końcu, wyznaczając m[n-1] i podstawiając do równania dla węzła n-2
+		a, b := h[n-3], h[n-2]
+		diag[n-2] += sup[n-2] * (a + b) / a
+		sub[n-2] -= sup[n-2] * b / a
+
+		m := make([]float64, n)
+		copy(m[1:n-1], solveTridiagonal(sub[1:n-1], diag[1:n-1], sup[1:n-1], rhs[1:n-1]))
+		m[0] = ((h[0]+h[1])*m[1] - h[0]*m[2]) / h[1]
+		m[n-1] = ((a+b)*m[n-2] - b*m[n-3]) / a
+		s.pp = s.piecewise(m)
+		return nil
+	default:
+		// Warunek naturalny, a także "not-a-knot" dla dwóch punktów, gdzie splajn jest odcinkiem
+		diag[0] = 1
+		diag[n-1] = 1
+	}
+
+	s.pp = s.piecewise(solveTridiagonal(sub, diag, sup, rhs))
+	return nil
+}
+
+// piecewise zapisuje splajn jako wielomiany sześcienne kolejnych przedziałów na podstawie drugich pochodnych m.
+func (s *Spline) piecewise(m []float64) *interpolate.Piecewise {
+	n := len(s.XYPairs)
+	pp := &interpolate.Piecewise{Breaks: make([]float64, n), Coef: make([][]float64, n-1)}
+	for i, p := range s.XYPairs {
+		pp.Breaks[i] = p.X
+	}
+
+	for i := 0; i < n-1; i++ {
+		l, r := s.XYPairs[i], s.XYPairs[i+1]
+		h := r.X - l.X
+		pp.Coef[i] = []float64{
+			l.Y,
+			(r.Y-l.Y)/h - h*(2*m[i]+m[i+1])/6,
+			m[i] / 2,
+			(m[i+1] - m[i]) / (6 * h),
+		}
+	}
+	return pp
+}
+
+// solveTridiagonal rozwiązuje układ trójprzekątniowy algorytmem Thomasa.
+func solveTridiagonal(sub, diag, sup, rhs []float64) []float64 {
+	n := len(diag)
+	c := make([]float64, n)
+	d := make([]float64, n)
+
+	c[0] = sup[0] / diag[0]
+	d[0] = rhs[0] / diag[0]
+	for i := 1; i < n; i++ {
+		den := diag[i] - sub[i]*c[i-1]
+		c[i] = sup[i] / den
+		d[i] = (rhs[i] - sub[i]*d[i-1]) / den
+	}
+
+	x := make([]float64, n)
+	x[n-1] = d[n-1]
+	for i := n - 2; i >= 0; i-- {
+		x[i] = d[i] - c[i]*x[i+1]
+	}
+	return x
+}
+
+func (s *Spline) Interpolate(val float64) float64 {
+	if est, ok := s.Extrapolate(val, s.Interpolate); ok {
+		return est
+	}
+	return s.pp.Eval(val)
+}
+
+func (s *Spline) Validate(val float64) error {
+
+	if s.pp == nil {
+		return fmt.Errorf("Splajn nie został dopasowany")
+	}
+
+	return s.ValidateRange(val)
+}
+
+// Derivative zwraca pochodną splajnu rzędu order w punkcie x oraz błąd (jeśli istnieje).
+func (s *Spline) Derivative(x float64, order int) (float64, error) {
+	if err := s.Validate(x); err != nil {
+		return 0, err
+	}
+	return s.DerivativeOf(x, order, s.pp.Derivative)
+}
+
+// Integrate zwraca całkę oznaczoną splajnu od from do to oraz błąd (jeśli istnieje).
+func (s *Spline) Integrate(from, to float64) (float64, error) {
+	if err := s.Validate(from); err != nil {
+		return 0, err
+	}
+	if err := s.Validate(to); err != nil {
+		return 0, err
+	}
+	return s.IntegralOf(from, to, s.pp.Integrate), nil
+}
@@ -0,0 +1,264 @@
+package spline_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/spline"
+	"github.com/stretchr/testify/assert"
+)
+
+func cubic(x float64) float64 {
+	return x*x*x - 2*x*x + 3*x - 1
+}
+
+func cubicSlope(x float64) float64 {
+	return 3*x*x - 4*x + 3
+}
+
+func TestSplineCanFit(t *testing.T) {
+	cases := map[string]struct {
+		x             []float64
+		y             []float64
+		expectedError error
+	}{
+		"basic spline fit": {
+			x:             []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 6.2},
+			y:             []float64{3.37, 4.45, 4.81, 3.96, 3.31, 2.72, 3.02, 3.43, 4.07},
+			expectedError: nil,
+		},
+		"wrong x and y size": {
+			x:             []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 4.07},
+			y:             []float64{3.37, 4.45, 4.81, 3.96, 3.31},
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"single point": {
+			x:             []float64{1.3},
+			y:             []float64{3.37},
+			expectedError: fmt.Errorf("Do interpolacji splajnem potrzebne są co najmniej 2 punkty"),
+		},
+		"same x values": {
+			x:             []float64{1.3, 1.8, 1.8},
+			y:             []float64{3.37, 4.45, 4.81},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same wartości X"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := spline.New()
+			err := s.Fit(c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestSplineCanInterpolateSingleValue(t *testing.T) {
+	cubicX := []float64{0, 0.5, 1.5, 2, 3, 4.5}
+	cubicY := make([]float64, len(cubicX))
+	for i, x := range cubicX {
+		cubicY[i] = cubic(x)
+	}
+
+	cases := map[string]struct {
+		spline             *spline.Spline
+		x                  []float64
+		y                  []float64
+		valueToInterpolate float64
+		expectedEstimate   float64
+		expectedError      error
+	}{
+		"natural spline through three points": {
+			spline:             spline.New(),
+			x:                  []float64{0, 1, 2},
+			y:                  []float64{0, 1, 0},
+			valueToInterpolate: 0.5,
+			expectedEstimate:   0.6875,
+			expectedError:      nil,
+		},
+		"natural spline with unsorted x and y": {
+			spline:             spline.New(),
+			x:                  []float64{2, 0, 1},
+			y:                  []float64{0, 0, 1},
+			valueToInterpolate: 1.5,
+			expectedEstimate:   0.6875,
+			expectedError:      nil,
+		},
+		"natural spline reproduces a line": {
+			spline:             spline.New(),
+			x:                  []float64{0, 1, 3, 4},
+			y:                  []float64{1, 3, 7, 9},
+			valueToInterpolate: 2.2,
+			expectedEstimate:   5.4,
+			expectedError:      nil,
+		},
+		"clamped spline reproduces a cubic": {
+			spline:             spline.NewClamped(cubicSlope(0), cubicSlope(4.5)),
+			x:                  cubicX,
+			y:                  cubicY,
+			valueToInterpolate: 2.7,
+			expectedEstimate:   cubic(2.7),
+			expectedError:      nil,
+		},
+		"not-a-knot spline reproduces a cubic": {
+			spline:             spline.NewNotAKnot(),
+			x:                  cubicX,
+			y:                  cubicY,
+			valueToInterpolate: 0.2,
+			expectedEstimate:   cubic(0.2),
+			expectedError:      nil,
+		},
+		"not-a-knot spline on equally spaced points": {
+			spline:             spline.NewNotAKnot(),
+			x:                  []float64{-1, 0, 1, 2},
+			y:                  []float64{cubic(-1), cubic(0), cubic(1), cubic(2)},
+			valueToInterpolate: 1.5,
+			expectedEstimate:   cubic(1.5),
+			expectedError:      nil,
+		},
+		"not-a-knot spline through three points is a parabola": {
+			spline:             spline.NewNotAKnot(),
+			x:                  []float64{0, 1, 3},
+			y:                  []float64{1, 2, 10},
+			valueToInterpolate: 2,
+			expectedEstimate:   5,
+			expectedError:      nil,
+		},
+		"big value to interpolate test": {
+			spline:             spline.New(),
+			x:                  []float64{1.8, 4.9, 2.5, 1.3, 4.4, 3.1, 3.8, 5.5, 6.2},
+			y:                  []float64{4.45, 3.02, 4.81, 3.37, 2.72, 3.96, 3.31, 3.43, 4.07},
+			valueToInterpolate: 1000,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+		"too small value to interpolate test": {
+			spline:             spline.New(),
+			x:                  []float64{1.8, 4.9, 2.5, 1.3, 4.4, 3.1, 3.8, 5.5, 6.2},
+			y:                  []float64{4.45, 3.02, 4.81, 3.37, 2.72, 3.96, 3.31, 3.43, 4.07},
+			valueToInterpolate: 1.2,
+			expectedEstimate:   0,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			c.spline.Fit(c.x, c.y)
+			estimate, err := interpolate.WithSingle(c.spline, c.valueToInterpolate)
+			assert.InDelta(t, c.expectedEstimate, estimate, 1e-10)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestSplineCanInterpolateMultipleValues(t *testing.T) {
+	cases := map[string]struct {
+		x                   []float64
+		y                   []float64
+		valuesToInterpolate []float64
+		expectedEstimates   []float64
+		expectedError       error
+	}{
+		"spline passes through the knots": {
+			x:                   []float64{1.3, 1.8, 2.5, 3.1, 3.8, 4.4, 4.9, 5.5, 6.2},
+			y:                   []float64{3.37, 4.45, 4.81, 3.96, 3.31, 2.72, 3.02, 3.43, 4.07},
+			valuesToInterpolate: []float64{1.3, 2.5, 4.9, 6.2},
+			expectedEstimates:   []float64{3.37, 4.81, 3.02, 4.07},
+			expectedError:       nil,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := spline.New()
+			s.Fit(c.x, c.y)
+			estimates, err := interpolate.WithMulti(s, c.valuesToInterpolate)
+			assert.Equal(t, len(c.expectedEstimates), len(estimates))
+			for i := range estimates {
+				assert.InDelta(t, c.expectedEstimates[i], estimates[i], 1e-12)
+			}
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestSplineValidateBeforeFit(t *testing.T) {
+	s := spline.New()
+	_, err := interpolate.WithSingle(s, 1)
+	assert.Equal(t, fmt.Errorf("Splajn nie został dopasowany"), err)
+}
+
+func TestSplineDerivativeAndIntegral(t *testing.T) {
+	x := []float64{0, 0.5, 1.5, 2, 3, 4.5}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = cubic(x[i])
+	}
+
+	cases := map[string]struct {
+		spline             *spline.Spline
+		order              int
+		valueToInterpolate float64
+		expectedDerivative float64
+	}{
+		"not-a-knot slope": {
+			spline:             spline.NewNotAKnot(),
+			order:              1,
+			valueToInterpolate: 2.7,
+			expectedDerivative: cubicSlope(2.7),
+		},
+		"not-a-knot second derivative at node": {
+			spline:             spline.NewNotAKnot(),
+			order:              2,
+			valueToInterpolate: 1.5,
+			expectedDerivative: 6*1.5 - 4,
+		},
+		"not-a-knot third derivative": {
+			spline:             spline.NewNotAKnot(),
+			order:              3,
+			valueToInterpolate: 4,
+			expectedDerivative: 6,
+		},
+		"clamped slope at the end": {
+			spline:             spline.NewClamped(cubicSlope(0), cubicSlope(4.5)),
+			order:              1,
+			valueToInterpolate: 4.5,
+			expectedDerivative: cubicSlope(4.5),
+		},
+		"natural second derivative at the end": {
+			spline:             spline.New(),
+			order:              2,
+			valueToInterpolate: 4.5,
+			expectedDerivative: 0,
+		},
+		"fourth derivative": {
+			spline:             spline.New(),
+			order:              4,
+			valueToInterpolate: 1,
+			expectedDerivative: 0,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.spline.Fit(x, y)
+			assert.Nil(t, err)
+
+			d, err := c.spline.Derivative(c.valueToInterpolate, c.order)
+			assert.Nil(t, err)
+			assert.InDelta(t, c.expectedDerivative, d, 1e-9)
+		})
+	}
+
+	s := spline.NewNotAKnot()
+	assert.Nil(t, s.Fit(x, y))
+	area, err := s.Integrate(0.2, 4.1)
+	assert.Nil(t, err)
+
+	antiderivative := func(x float64) float64 {
+		return x*x*x*x/4 - 2*x*x*x/3 + 3*x*x/2 - x
+	}
+	assert.InDelta(t, antiderivative(4.1)-antiderivative(0.2), area, 1e-9)
+}
@@ -0,0 +1,168 @@
+package step
+
+import (
+	"fmt"
+
+	"github.com/53jk1/pok2/interpolate"
+)
+
+func init() {
+	interpolate.Register("nearest", func() interpolate.Interpolator { return NewNearest() })
+	interpolate.Register("previous", func() interpolate.Interpolator { return NewPrevious() })
+	interpolate.Register("next", func() interpolate.Interpolator { return NewNext() })
+}
+
+// Mode określa, z którego węzła brana jest wartość funkcji schodkowej
+type Mode int
+
+const (
+	// Nearest zwraca wartość najbliższego węzła; w połowie przedziału wybierany jest węzeł lewy.
+	Nearest Mode = iota
+	// Previous zwraca wartość ostatniego węzła o X <= val, tzn. wartość obowiązującą od lewej.
+	Previous
+	// Next zwraca wartość pierwszego węzła o X >= val, tzn. wartość obowiązującą od prawej.
+	Next
+)
+
+// Step zapewnia interpolację funkcją schodkową (kawałkami stałą).
+// Biorąc pod uwagę wycinki X i Y float64, zwraca wartość Y wybranego węzła bez wygładzania przejść.
+type Step struct {
+	interpolate.Base
+	Mode Mode
+
+	fitted bool
+	pp     *interpolate.Piecewise
+}
+
+// NewNearest zwraca nowy obiekt Step z trybem Nearest.
+func NewNearest() *Step {
+	s := &Step{Mode: Nearest}
+	return s
+}
+
+// NewPrevious zwraca nowy obiekt Step z trybem Previous.
+func NewPrevious() *Step {
+	s := &Step{Mode: Previous}
+	return s
+}
+
+// NewNext zwraca nowy obiekt Step z trybem Next.
+func NewNext() *Step {
+	s := &Step{Mode: Next}
+	return s
+}
+
+// Fit otrzymuje wycinki współrzędnych x i y oraz sortuje punkty.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, brak punktów lub wartości X się powtarzają.
+func (s *Step) Fit(x, y []float64) error {
+	s.fitted = false
+	s.pp = nil
+
+	if err := s.Base.Fit(x, y); err != nil {
+		return err
+	}
+
+	n := len(s.XYPairs)
+	if n == 0 {
+		return fmt.Errorf("Do interpolacji schodkowej potrzebny jest co najmniej 1 punkt")
+	}
+
+	for i := 1; i < n; i++ {
+		if s.XYPairs[i].X == s.XYPairs[i-1].X {
+			return fmt.Errorf("Istnieją co najmniej 2 takie same wartości X")
+		}
+	}
+
+	if n > 1 {
+		s.pp = s.piecewise()
+	}
+	s.fitted = true
+	return nil
+}
+
+// piecewise zapisuje funkcję schodkową jako wielomiany stopnia zerowego; dla Nearest
+// każdy przedział między węzłami dzielony jest w połowie.
+func (s *Step) piecewise() *interpolate.Piecewise {
+	pp := &interpolate.Piecewise{}
+	for i := 0; i < len(s.XYPairs)-1; i++ {
+		l, r := s.XYPairs[i], s.XYPairs[i+1]
+		switch s.Mode {
+		case Previous:
+			pp.Breaks = append(pp.Breaks, l.X)
+			pp.Coef = append(pp.Coef, []float64{l.Y})
+		case Next:
+			pp.Breaks = append(pp.Breaks, l.X)
+			pp.Coef = append(pp.Coef, []float64{r.Y})
+		default:
+			pp.Breaks = append(pp.Breaks, l.X, (l.X+r.X)/2)
+			pp.Coef = append(pp.Coef, []float64{l.Y}, []float64{r.Y})
+		}
+	}
+	pp.Breaks = append(pp.Breaks, s.XYPairs[len(s.XYPairs)-1].X)
+	return pp
+}
+
+func (s *Step) Interpolate(val float64) float64 {
+	if est, ok := s.Extrapolate(val, s.Interpolate); ok {
+		return est
+	}
+
+	if len(s.XYPairs) == 1 {
+		return s.XYPairs[0].Y
+	}
+
+	i := s.Segment(val)
+	l, r := s.XYPairs[i], s.XYPairs[i+1]
+
+	switch s.Mode {
+	case Previous:
+		if val >= r.X {
+			return r.Y
+		}
+		return l.Y
+	case Next:
+		if val <= l.X {
+			return l.Y
+		}
+		return r.Y
+	}
+
+	if val-l.X <= r.X-val {
+		return l.Y
+	}
+	return r.Y
+}
+
+func (s *Step) Validate(val float64) error {
+
+	if !s.fitted {
+		return fmt.Errorf("Interpolant schodkowy nie został dopasowany")
+	}
+
+	return s.ValidateRange(val)
+}
+
+// Derivative zwraca pochodną rzędu order w punkcie x oraz błąd (jeśli istnieje).
+// Poza punktami skoku pochodne funkcji schodkowej są zerowe; w punktach skoku również zwracane jest zero.
+func (s *Step) Derivative(x float64, order int) (float64, error) {
+	if err := s.Validate(x); err != nil {
+		return 0, err
+	}
+	return s.DerivativeOf(x, order, func(x float64, order int) float64 {
+		if order == 0 {
+			return s.Interpolate(x)
+		}
+		return 0
+	})
+}
+
+// Integrate zwraca całkę oznaczoną funkcji schodkowej od from do to oraz błąd (jeśli istnieje).
+func (s *Step) Integrate(from, to float64) (float64, error) {
+	if err := s.Validate(from); err != nil {
+		return 0, err
+	}
+	if err := s.Validate(to); err != nil {
+		return 0, err
+	}
+	return s.IntegralOf(from, to, s.pp.Integrate), nil
+}
@@ -0,0 +1,175 @@
+package step_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2/interpolate"
+	"github.com/53jk1/pok2/interpolate/step"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepCanFit(t *testing.T) {
+	cases := map[string]struct {
+		x             []float64
+		y             []float64
+		expectedError error
+	}{
+		"basic step fit": {
+			x:             []float64{0, 1, 3},
+			y:             []float64{10, 20, 30},
+			expectedError: nil,
+		},
+		"wrong x and y size": {
+			x:             []float64{0, 1, 3},
+			y:             []float64{10, 20},
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"no points": {
+			x:             []float64{},
+			y:             []float64{},
+			expectedError: fmt.Errorf("Do interpolacji schodkowej potrzebny jest co najmniej 1 punkt"),
+		},
+		"same x values": {
+			x:             []float64{0, 1, 1},
+			y:             []float64{10, 20, 30},
+			expectedError: fmt.Errorf("Istnieją co najmniej 2 takie same wartości X"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := step.NewNearest()
+			err := s.Fit(c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestStepCanInterpolateMultipleValues(t *testing.T) {
+	x := []float64{3, 0, 1}
+	y := []float64{30, 10, 20}
+	vals := []float64{0, 0.4, 0.5, 0.6, 1, 2, 2.5, 3}
+
+	cases := map[string]struct {
+		step              *step.Step
+		expectedEstimates []float64
+	}{
+		"nearest": {
+			step:              step.NewNearest(),
+			expectedEstimates: []float64{10, 10, 10, 20, 20, 20, 30, 30},
+		},
+		"previous": {
+			step:              step.NewPrevious(),
+			expectedEstimates: []float64{10, 10, 10, 10, 20, 20, 20, 30},
+		},
+		"next": {
+			step:              step.NewNext(),
+			expectedEstimates: []float64{10, 20, 20, 20, 20, 30, 30, 30},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.step.Fit(x, y)
+			assert.Nil(t, err)
+
+			estimates, err := interpolate.WithMulti(c.step, vals)
+			assert.Nil(t, err)
+			assert.Equal(t, c.expectedEstimates, estimates)
+		})
+	}
+}
+
+func TestStepValidate(t *testing.T) {
+	cases := map[string]struct {
+		x                  []float64
+		y                  []float64
+		valueToInterpolate float64
+		expectedEstimate   float64
+		expectedError      error
+	}{
+		"single point": {
+			x:                  []float64{2},
+			y:                  []float64{7},
+			valueToInterpolate: 2,
+			expectedEstimate:   7,
+			expectedError:      nil,
+		},
+		"too small value to interpolate": {
+			x:                  []float64{0, 1},
+			y:                  []float64{10, 20},
+			valueToInterpolate: -0.5,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt mała i nie mieści się w zakresie"),
+		},
+		"too big value to interpolate": {
+			x:                  []float64{0, 1},
+			y:                  []float64{10, 20},
+			valueToInterpolate: 1.5,
+			expectedError:      fmt.Errorf("Wartość do interpolacji jest zbyt duża i nie mieści się w zakresie"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := step.NewPrevious()
+			assert.Equal(t, fmt.Errorf("Interpolant schodkowy nie został dopasowany"), s.Validate(c.valueToInterpolate))
+
+			err := s.Fit(c.x, c.y)
+			assert.Nil(t, err)
+
+			estimate, err := interpolate.WithSingle(s, c.valueToInterpolate)
+			assert.Equal(t, c.expectedError, err)
+			assert.Equal(t, c.expectedEstimate, estimate)
+		})
+	}
+}
+
+func TestStepDerivativeAndIntegral(t *testing.T) {
+	x := []float64{0, 1, 3}
+	y := []float64{10, 20, 30}
+
+	cases := map[string]struct {
+		step             *step.Step
+		from, to         float64
+		expectedIntegral float64
+	}{
+		"nearest": {
+			step:             step.NewNearest(),
+			from:             0,
+			to:               3,
+			expectedIntegral: 10*0.5 + 20*1.5 + 30*1,
+		},
+		"previous": {
+			step:             step.NewPrevious(),
+			from:             0.5,
+			to:               3,
+			expectedIntegral: 10*0.5 + 20*2,
+		},
+		"next": {
+			step:             step.NewNext(),
+			from:             3,
+			to:               0.5,
+			expectedIntegral: -(20*0.5 + 30*2),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.step.Fit(x, y)
+			assert.Nil(t, err)
+
+			area, err := c.step.Integrate(c.from, c.to)
+			assert.Nil(t, err)
+			assert.InDelta(t, c.expectedIntegral, area, 1e-12)
+
+			d, err := c.step.Derivative(2, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, 0.0, d)
+
+			d, err = c.step.Derivative(2, 0)
+			assert.Nil(t, err)
+			assert.Equal(t, c.step.Interpolate(2), d)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU przechowuje rozkład macierzy kwadratowej A w postaci P*A = L*U, uzyskany eliminacją Gaussa z częściowym wyborem elementu głównego.
+// L jest macierzą dolnotrójkątną z jedynkami na przekątnej, a U macierzą górnotrójkątną.
+// Pivot[i] to indeks wiersza macierzy A, który po zamianach trafił na pozycję i.
+type LU struct {
+	L     Matrix
+	U     Matrix
+	Pivot []int
+	sign  float64
+}
+
+// LU zwraca rozkład LU macierzy oraz błąd (jeśli istnieje).
+// Raz obliczony rozkład można wykorzystać do rozwiązania układu dla wielu prawych stron.
+func (m Matrix) LU() (*LU, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !m.isSquare() {
+		return nil, fmt.Errorf("Nie można rozłożyć macierzy niekwadratowej")
+	}
+
+	n, _ := m.Dim()
+	a := m.copy()
+
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+
+	for k := 0; k < n; k++ {
+
+		// Wybór elementu głównego w kolumnie k
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+				p = i
+			}
+		}
+
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+
+		// Kolumna jest zerowa poniżej przekątnej, więc nie ma czego eliminować
+		if a[k][k] == 0 {
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			a[i][k] /= a[k][k]
+			for j := k + 1; j < n; j++ {
+				a[i][j] -= a[i][k] * a[k][j]
+			}
+		}
+	}
+
+	l := make(Matrix, n)
+	u := make(Matrix, n)
+	for i := 0; i < n; i++ {
+		l[i] = make(Vector, n)
+		u[i] = make(Vector, n)
+		for j := 0; j < n; j++ {
+			switch {
+			case i > j:
+				l[i][j] = a[i][j]
+			case i == j:
+				l[i][j] = 1
+				u[i][j] = a[i][j]
+			default:
+				u[i][j] = a[i][j]
+			}
+		}
+	}
+
+	return &LU{L: l, U: u, Pivot: pivot, sign: sign}, nil
+}
+
+// P zwraca macierz permutacji odpowiadającą zamianom wierszy wykonanym podczas rozkładu.
+func (f *LU) P() Matrix {
+	n := len(f.Pivot)
+	p := make(Matrix, n)
+	for i := range p {
+		p[i] = make(Vector, n)
+		p[i][f.Pivot[i]] = 1
+	}
+	return p
+}
+
+// Det zwraca wyznacznik macierzy, z której powstał rozkład.
+func (f *LU) Det() float64 {
+	det := f.sign
+	for i := range f.U {
+		det *= f.U[i][i]
+	}
+	return det
+}
+
+// IsSingular zwraca prawdę, jeśli na przekątnej U znajduje się element bliski zeru.
+func (f *LU) IsSingular() bool {
+	for i := range f.U {
+		if math.Abs(f.U[i][i]) < 1e-10 {
+			return true
+		}
+	}
+	return false
+}
+
+// Solve otrzymuje macierz prawych stron B.
+// Rozwiązuje układ A * X = B podstawieniem w przód i wstecz, zwraca X oraz błąd (jeśli istnieje).
+func (f *LU) Solve(b Matrix) (Matrix, error) {
+	n := len(f.Pivot)
+
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	if rows, _ := b.Dim(); rows != n {
+		return nil, fmt.Errorf("Liczba wierszy prawej strony musi być równa rozmiarowi macierzy")
+	}
+
+	if f.IsSingular() {
+		return nil, fmt.Errorf("Macierz jest pojedyncza")
+	}
+
+	_, cols := b.Dim()
+	x := make(Matrix, n)
+	for i := 0; i < n; i++ {
+		x[i] = make(Vector, cols)
+		copy(x[i], b[f.Pivot[i]])
+	}
+
+	for c := 0; c < cols; c++ {
+
+		// L * Y = P * B
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				x[i][c] -= f.L[i][j] * x[j][c]
+			}
+		}
+
+		// U * X = Y
+		for i := n - 1; i >= 0; i-- {
+			for j := i + 1; j < n; j++ {
+				x[i][c] -= f.U[i][j] * x[j][c]
+			}
+			x[i][c] /= f.U[i][i]
+		}
+	}
+
+	return x, nil
+}
+
+// Inverse zwraca macierz odwrotną wyznaczoną z rozkładu oraz błąd (jeśli istnieje).
+func (f *LU) Inverse() (Matrix, error) {
+	return f.Solve(identity(len(f.Pivot)))
+}
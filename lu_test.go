@@ -0,0 +1,144 @@
+package pok2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixLU(t *testing.T) {
+	cases := map[string]struct {
+		matrix        pok2.Matrix
+		expectedDet   float64
+		expectedError error
+	}{
+		"basic lu decomposition": {
+			matrix: pok2.Matrix{
+				{4, 7},
+				{2, 6},
+			},
+			expectedDet:   10,
+			expectedError: nil,
+		},
+		"lu decomposition with row swaps": {
+			matrix: pok2.Matrix{
+				{0, 2, 1},
+				{1, 1, 0},
+				{3, 0, 1},
+			},
+			expectedDet:   -5,
+			expectedError: nil,
+		},
+		"lu decomposition of singular matrix": {
+			matrix: pok2.Matrix{
+				{2, 4},
+				{6, 12},
+			},
+			expectedDet:   0,
+			expectedError: nil,
+		},
+		"lu decomposition of non-square matrix": {
+			matrix: pok2.Matrix{
+				{4, 7},
+			},
+			expectedError: fmt.Errorf("Nie można rozłożyć macierzy niekwadratowej"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			lu, err := c.matrix.LU()
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			pa, _ := lu.P().MultiplyBy(c.matrix)
+			product, _ := lu.L.MultiplyBy(lu.U)
+			assert.Equal(t, true, product.IsSimilar(pa, 1e-10))
+			assert.InDelta(t, c.expectedDet, lu.Det(), 1e-10)
+		})
+	}
+}
+
+func TestLUSolve(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		rhs            pok2.Matrix
+		expectedResult pok2.Matrix
+		expectedError  error
+	}{
+		"solving with multiple right-hand sides": {
+			matrix: pok2.Matrix{
+				{0, 2, 1},
+				{1, 1, 0},
+				{3, 0, 1},
+			},
+			rhs: pok2.Matrix{
+				{3, 1},
+				{2, 0},
+				{4, 1},
+			},
+			expectedResult: pok2.Matrix{
+				{1, 0},
+				{1, 0},
+				{1, 1},
+			},
+			expectedError: nil,
+		},
+		"solving singular system": {
+			matrix: pok2.Matrix{
+				{2, 4},
+				{6, 12},
+			},
+			rhs: pok2.Matrix{
+				{1},
+				{1},
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Macierz jest pojedyncza"),
+		},
+		"solving with wrong right-hand side dimensions": {
+			matrix: pok2.Matrix{
+				{4, 7},
+				{2, 6},
+			},
+			rhs: pok2.Matrix{
+				{1},
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Liczba wierszy prawej strony musi być równa rozmiarowi macierzy"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			lu, _ := c.matrix.LU()
+			solved, err := lu.Solve(c.rhs)
+			assert.Equal(t, true, solved.IsSimilar(c.expectedResult, 1e-10))
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestLUInverse(t *testing.T) {
+	m := pok2.Matrix{
+		{3, 0, 2},
+		{2, 0, -2},
+		{0, 1, 1},
+	}
+	expected := pok2.Matrix{
+		{0.2, 0.2, 0},
+		{-0.2, 0.3, 1},
+		{0.2, -0.3, 0},
+	}
+
+	lu, err := m.LU()
+	assert.Nil(t, err)
+
+	inverted, err := lu.Inverse()
+	assert.Nil(t, err)
+	assert.Equal(t, true, inverted.IsSimilar(expected, 1e-10))
+}
@@ -1,23 +1,71 @@
 package pok2
 
 import (
+	"errors"
 	"fmt"
 	"math"
 )
 
+// eps to odstęp między 1 a następną liczbą float64, używany do wyznaczania tolerancji numerycznych.
+const eps = 2.220446049250313e-16
+
 // Typ macierzy to wycinek wektorów z niestandardowymi metodami potrzebnymi do operacji na macierzach.
 type Matrix []Vector
 
+// ErrEmptyMatrix jest zwracany, gdy macierz nie ma żadnego wiersza lub kolumny.
+var ErrEmptyMatrix = errors.New("Macierz jest pusta")
+
+// RaggedError jest zwracany, gdy wiersze macierzy mają różne długości.
+type RaggedError struct {
+	Row  int
+	Len  int
+	Cols int
+}
+
+func (e *RaggedError) Error() string {
+	return fmt.Sprintf("Wiersze macierzy mają różne długości (wiersz %d ma %d elementów, a pierwszy wiersz %d)", e.Row, e.Len, e.Cols)
+}
+
 // Dim zwraca wymiary macierzy w postaci (wiersze, kolumny).
+// Liczba kolumn odczytywana jest z pierwszego wiersza; to, czy pozostałe wiersze mają tę samą długość, sprawdza Validate.
 func (m Matrix) Dim() (int, int) {
-	if m.isNil() {
+	if len(m) == 0 {
 		return 0, 0
 	}
 	return len(m), len(m[0])
 }
 
-// Invert zwraca odwróconą macierz przy użyciu eliminacji Gaussa-Jordana
+// Validate sprawdza, czy macierz ma co najmniej jeden wiersz i jedną kolumnę oraz czy wszystkie wiersze mają tę samą długość.
+// Zwraca ErrEmptyMatrix, błąd typu *RaggedError lub nil.
+func (m Matrix) Validate() error {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return ErrEmptyMatrix
+	}
+	for i := range m {
+		if len(m[i]) != len(m[0]) {
+			return &RaggedError{Row: i, Len: len(m[i]), Cols: len(m[0])}
+		}
+	}
+	return nil
+}
+
+// Invert zwraca odwróconą macierz przy użyciu eliminacji Gaussa-Jordana.
+// Macierz, na której wywołano metodę, pozostaje niezmieniona.
 func (m Matrix) Invert() (Matrix, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m.copy().InvertInPlace()
+}
+
+// InvertInPlace odwraca macierz w miejscu przy użyciu eliminacji Gaussa-Jordana, bez alokowania nowej macierzy.
+// Zwraca odwróconą macierz (współdzielącą pamięć z macierzą wejściową) i błąd.
+// W przypadku błędu zawartość macierzy jest nieokreślona.
+func (m Matrix) InvertInPlace() (Matrix, error) {
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
 
 	if !m.isSquare() {
 		return nil, fmt.Errorf("Nie można odwrócić macierzy niekwadratowej")
@@ -56,14 +104,10 @@ func (m Matrix) Invert() (Matrix, error) {
 		m[currentRow-1][currentRow-1] = 1.0
 
 		// Dzielenie przez mi
-		div, err := m[currentRow-1].DivideByScalar(mi)
-
-		if err != nil {
-			return nil, err
+		for j := range m[currentRow-1] {
+			m[currentRow-1][j] /= mi
 		}
 
-		m[currentRow-1] = div
-
 		for i := 1; i <= rows; i++ {
 			if i != currentRow {
 				mi = m[i-1][currentRow-1]
@@ -91,10 +135,9 @@ func (m Matrix) Invert() (Matrix, error) {
 
 // Log stosuje logarytm naturalny do wszystkich elementów macierzy i zwraca wynikową macierz.
 func (m Matrix) Log() Matrix {
-	row, col := m.Dim()
-	result := make(Matrix, row)
+	result := make(Matrix, len(m))
 	for i := range m {
-		result[i] = make(Vector, col)
+		result[i] = make(Vector, len(m[i]))
 		for j := range m[i] {
 			result[i][j] = math.Log(m[i][j])
 		}
@@ -104,10 +147,9 @@ func (m Matrix) Log() Matrix {
 
 // Exp stosuje e^x do wszystkich elementów macierzy i zwraca wynikową macierz.
 func (m Matrix) Exp() Matrix {
-	row, col := m.Dim()
-	result := make(Matrix, row)
+	result := make(Matrix, len(m))
 	for i := range m {
-		result[i] = make(Vector, col)
+		result[i] = make(Vector, len(m[i]))
 		for j := range m[i] {
 			result[i][j] = math.Exp(m[i][j])
 		}
@@ -117,46 +159,71 @@ func (m Matrix) Exp() Matrix {
 
 // LeftDivide otrzymuje inną macierz jako parametr.
 // Metoda rozwiązuje symboliczny układ równań liniowych w postaci macierzowej, A * X = B dla X.
-// Zwraca wyniki w postaci macierzowej i błędu (jeśli istnieje).
+// Układ nadokreślony rozwiązywany jest metodą najmniejszych kwadratów z użyciem rozkładu QR.
+// Zwraca wyniki w postaci macierzowej i błędu (jeśli istnieje). Jeśli A nie ma pełnego rzędu kolumnowego, błąd jest typu *RankError.
 func (m Matrix) LeftDivide(m2 Matrix) (Matrix, error) {
-	var r Matrix
-	mT, err := m.Transpose()
-
-	if err != nil {
-		return r, err
+	if err := m.Validate(); err != nil {
+		return nil, err
 	}
-
-	mtm, err := mT.MultiplyBy(m)
-
-	if err != nil {
-		return r, err
+	if err := m2.Validate(); err != nil {
+		return nil, err
 	}
 
-	pInv, err := mtm.Invert()
-	if err != nil {
-		return r, err
+	rows, _ := m.Dim()
+	rows2, _ := m2.Dim()
+
+	if rows != rows2 {
+		return nil, fmt.Errorf("Liczba wierszy pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy")
 	}
 
-	pmt, err := pInv.MultiplyBy(mT)
+	return m.SolveLeastSquares(m2)
+}
 
-	if err != nil {
-		return r, err
+// WeightedLeftDivide otrzymuje macierz prawych stron B i wektor wag w.
+// Rozwiązuje ważony układ A * X = B metodą najmniejszych kwadratów, minimalizując sum(w[i] * |A[i] * X - B[i]|^2).
+// Zamiast macierzy diagonalnej wag mnoży kopie i-tych wierszy A i B przez sqrt(w[i]) i przekazuje je do LeftDivide.
+// Dla wariancji pomiarów s[i]^2 należy przyjąć w[i] = 1 / s[i]^2. Wiersze o wadze 0 nie wpływają na rozwiązanie.
+// Zwraca błędy jak LeftDivide oraz błąd, gdy liczba wag nie jest równa liczbie wierszy A i B lub któraś waga jest ujemna.
+func (m Matrix) WeightedLeftDivide(m2 Matrix, w Vector) (Matrix, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if err := m2.Validate(); err != nil {
+		return nil, err
 	}
 
-	r, err = pmt.MultiplyBy(m2)
+	rows, _ := m.Dim()
+	rows2, _ := m2.Dim()
+	if rows != rows2 {
+		return nil, fmt.Errorf("Liczba wierszy pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy")
+	}
+	if len(w) != rows {
+		return nil, fmt.Errorf("Liczba wag musi być równa liczbie wierszy macierzy")
+	}
+	for i := range w {
+		if !(w[i] >= 0) || math.IsInf(w[i], 1) {
+			return nil, fmt.Errorf("Wagi muszą być nieujemnymi liczbami skończonymi")
+		}
+	}
 
-	if err != nil {
-		return r, err
+	a, b := m.copy(), m2.copy()
+	for i := range w {
+		s := math.Sqrt(w[i])
+		for j := range a[i] {
+			a[i][j] *= s
+		}
+		for j := range b[i] {
+			b[i][j] *= s
+		}
 	}
 
-	return r, nil
+	return a.LeftDivide(b)
 }
 
 func (m Matrix) sumAbs() float64 {
 	var sum float64
-	rows, cols := m.Dim()
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
+	for i := range m {
+		for j := range m[i] {
 			sum += math.Abs(m[i][j])
 		}
 	}
@@ -170,32 +237,51 @@ func (m Matrix) isSquare() bool {
 
 // MultiplyBy otrzymuje inną macierz jako parametr.
 // Mnoży macierze i zwraca wynikową macierz i błąd.
+// Iloczyn liczony jest w kolejności i-k-j, więc obie macierze odczytywane są wierszami bez alokowania kolumn.
+// Duże iloczyny przekazywane są do Dense.MultiplyBy, który liczy je blokowo i równolegle.
 func (m Matrix) MultiplyBy(m2 Matrix) (Matrix, error) {
 	var r Matrix
 
+	if err := m.Validate(); err != nil {
+		return r, err
+	}
+	if err := m2.Validate(); err != nil {
+		return r, err
+	}
+
 	_, cols1 := m.Dim()
-	rows2, _ := m2.Dim()
+	rows2, cols2 := m2.Dim()
 
 	if cols1 != rows2 {
 		return r, fmt.Errorf("Liczba kolumn pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy")
 	}
 
-	for currentRowIndex := range m {
-		r = append(r, Vector{})
-		for currentColumnIndex := range m2[0] {
-			m2Col, err := m2.Col(currentColumnIndex)
-
-			if err != nil {
-				return r, err
-			}
+	// Duże iloczyny liczone są blokowo i równolegle na macierzach gęstych
+	if len(m)*cols1*cols2 >= parallelMultiplyThreshold {
+		d1, err := m.ToDense()
+		if err != nil {
+			return r, err
+		}
+		d2, err := m2.ToDense()
+		if err != nil {
+			return r, err
+		}
+		product, err := d1.MultiplyBy(d2)
+		if err != nil {
+			return r, err
+		}
+		return product.ToMatrix(), nil
+	}
 
-			dot, err := m[currentRowIndex].Dot(m2Col)
-			if err != nil {
-				return r, err
+	r = make(Matrix, len(m))
+	for i := range m {
+		out := make(Vector, cols2)
+		for k, mik := range m[i] {
+			for j, val := range m2[k] {
+				out[j] += mik * val
 			}
-
-			r[currentRowIndex] = append(r[currentRowIndex], dot)
 		}
+		r[i] = out
 	}
 
 	return r, nil
@@ -206,6 +292,10 @@ func (m Matrix) MultiplyBy(m2 Matrix) (Matrix, error) {
 func (m Matrix) InsertCol(k int, c Vector) (Matrix, error) {
 	var r Matrix
 
+	if err := m.Validate(); err != nil {
+		return r, err
+	}
+
 	if k < 0 {
 		return r, fmt.Errorf("Indeks nie może być mniejszy niż 0")
 	} else if _, width := m.Dim(); k > width {
@@ -216,7 +306,10 @@ func (m Matrix) InsertCol(k int, c Vector) (Matrix, error) {
 
 	for i := 0; i < len(m); i++ {
 		row := m[i]
-		expRow := append(row[:k], append(Vector{c[i]}, row[k:]...)...)
+		expRow := make(Vector, 0, len(row)+1)
+		expRow = append(expRow, row[:k]...)
+		expRow = append(expRow, c[i])
+		expRow = append(expRow, row[k:]...)
 		r = append(r, expRow)
 	}
 
@@ -224,14 +317,16 @@ func (m Matrix) InsertCol(k int, c Vector) (Matrix, error) {
 }
 
 // Row otrzymuje indeks jako parametr.
-// Zwraca wiersz wektora pod podanym indeksem i błąd (jeśli istnieje).
+// Zwraca kopię wiersza wektora pod podanym indeksem i błąd (jeśli istnieje).
 func (m Matrix) Row(i int) (Vector, error) {
 	if i < 0 {
 		return nil, fmt.Errorf("Indeks nie może być ujemny")
-	} else if i > len(m) {
+	} else if i >= len(m) {
 		return nil, fmt.Errorf("Indeks nie może być większy niż długość")
 	}
-	return m[i], nil
+	r := make(Vector, len(m[i]))
+	copy(r, m[i])
+	return r, nil
 }
 
 // Col otrzymuje indeks jako parametr.
@@ -239,9 +334,13 @@ func (m Matrix) Row(i int) (Vector, error) {
 func (m Matrix) Col(i int) (Vector, error) {
 	var r Vector
 
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
 	if i < 0 {
 		return nil, fmt.Errorf("Indeks nie może być ujemny")
-	} else if i > len(m[0]) {
+	} else if i >= len(m[0]) {
 		return nil, fmt.Errorf("Indeks nie może być większy niż długość")
 	}
 
@@ -256,6 +355,10 @@ func (m Matrix) Col(i int) (Vector, error) {
 func (m Matrix) Transpose() (Matrix, error) {
 	var t Matrix
 
+	if err := m.Validate(); err != nil {
+		return t, err
+	}
+
 	for columnIndex := range m[0] {
 		column, err := m.Col(columnIndex)
 		if err != nil {
@@ -314,12 +417,13 @@ func (m Matrix) IsEqual(m2 Matrix) bool {
 // Add otrzymuje inną macierz jako parametr.
 // Dodaje dwie macierze i zwraca macierz wyników oraz błąd (jeśli taki istnieje).
 func (m Matrix) Add(m2 Matrix) (Matrix, error) {
-	rows, cols := m.Dim()
-	var r = make(Matrix, rows, cols)
 	if ok, err := m.canPerformOperationsWith(m2); !ok {
 		return nil, err
 	}
 
+	rows, _ := m.Dim()
+	var r = make(Matrix, rows)
+
 	for row := range m {
 		for col := range m[row] {
 			r[row] = append(r[row], m[row][col]+m2[row][col])
@@ -332,12 +436,13 @@ func (m Matrix) Add(m2 Matrix) (Matrix, error) {
 // Subtract otrzymuje inną macierz jako parametr.
 // Odejmuje dwie macierze i zwraca macierz wyników oraz błąd (jeśli istnieje).
 func (m Matrix) Subtract(m2 Matrix) (Matrix, error) {
-	rows, cols := m.Dim()
-	var r = make(Matrix, rows, cols)
 	if ok, err := m.canPerformOperationsWith(m2); !ok {
 		return nil, err
 	}
 
+	rows, _ := m.Dim()
+	var r = make(Matrix, rows)
+
 	for row := range m {
 		for col := range m[row] {
 			r[row] = append(r[row], m[row][col]-m2[row][col])
@@ -348,12 +453,14 @@ func (m Matrix) Subtract(m2 Matrix) (Matrix, error) {
 }
 
 func (m Matrix) areDimsEqual(m2 Matrix) bool {
-	mRows, mCols := m.Dim()
-	m2Rows, m2Cols := m2.Dim()
-
-	if mRows != m2Rows || mCols != m2Cols {
+	if len(m) != len(m2) {
 		return false
 	}
+	for i := range m {
+		if len(m[i]) != len(m2[i]) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -364,9 +471,34 @@ func (m Matrix) isNil() bool {
 	return false
 }
 
+func (m Matrix) copy() Matrix {
+	if m.isNil() {
+		return nil
+	}
+	c := make(Matrix, len(m))
+	for i := range m {
+		c[i] = make(Vector, len(m[i]))
+		copy(c[i], m[i])
+	}
+	return c
+}
+
+func identity(n int) Matrix {
+	id := make(Matrix, n)
+	for i := range id {
+		id[i] = make(Vector, n)
+		id[i][i] = 1
+	}
+	return id
+}
+
 func (m Matrix) canPerformOperationsWith(m2 Matrix) (bool, error) {
 	if m == nil || m2 == nil {
 		return false, fmt.Errorf("Macierze nie mogą być <nil>")
+	} else if err := m.Validate(); err != nil {
+		return false, err
+	} else if err := m2.Validate(); err != nil {
+		return false, err
 	} else if !m.areDimsEqual(m2) {
 		return false, fmt.Errorf("Wymiary macierzy muszą być zgodne")
 	}
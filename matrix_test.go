@@ -2,6 +2,7 @@ package pok2_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/53jk1/pok2"
@@ -176,6 +177,24 @@ func TestAddMatrices(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		"adding matrices with more rows than columns": {
+			matrix1: pok2.Matrix{
+				{1, 2},
+				{3, 4},
+				{5, 6},
+			},
+			matrix2: pok2.Matrix{
+				{1, 1},
+				{1, 1},
+				{1, 1},
+			},
+			expectedResult: pok2.Matrix{
+				{2, 3},
+				{4, 5},
+				{6, 7},
+			},
+			expectedError: nil,
+		},
 		// Wrong dimensions
 		// {
 		// 	matrix1: pok2.Matrix{
@@ -305,6 +324,21 @@ func TestCol(t *testing.T) {
 			expectedResult: nil,
 			expectedError:  fmt.Errorf("Index cannot be greater than the length"),
 		},
+		"getting column at index equal to the number of columns": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5, 6},
+			},
+			i:              3,
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Indeks nie może być większy niż długość"),
+		},
+		"getting column of empty matrix": {
+			matrix:         pok2.Matrix{},
+			i:              0,
+			expectedResult: nil,
+			expectedError:  pok2.ErrEmptyMatrix,
+		},
 	}
 
 	for name, c := range cases {
@@ -350,6 +384,15 @@ func TestRow(t *testing.T) {
 			expectedResult: nil,
 			expectedError:  fmt.Errorf("Index cannot be greater than the length"),
 		},
+		"getting the row at index equal to the number of rows": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5, 6},
+			},
+			i:              2,
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Indeks nie może być większy niż długość"),
+		},
 	}
 
 	for name, c := range cases {
@@ -402,15 +445,19 @@ func TestTransposeMatrix(t *testing.T) {
 			},
 			expectedError: nil,
 		},
-		// Inconsistent dimensions
-		// {
-		// 	matrix: pok2.Matrix{
-		// 		{1, 4},
-		// 		{2},
-		// 	},
-		// 	expectedResult:    nil,
-		// 	expectedError: fmt.Errorf("Inconsistent dimensions"),
-		// },
+		"transposing matrix with inconsistent dimensions": {
+			matrix: pok2.Matrix{
+				{1, 4},
+				{2},
+			},
+			expectedResult: nil,
+			expectedError:  &pok2.RaggedError{Row: 1, Len: 1, Cols: 2},
+		},
+		"transposing empty matrix": {
+			matrix:         pok2.Matrix{},
+			expectedResult: nil,
+			expectedError:  pok2.ErrEmptyMatrix,
+		},
 	}
 
 	for name, c := range cases {
@@ -578,12 +625,13 @@ func TestMatrixLeftDivide(t *testing.T) {
 				{3, 2},
 			},
 			expectedResult: nil,
-			expectedError:  fmt.Errorf("The number of columns of the 1st matrix must equal the number of rows of the 2nd matrix"),
+			expectedError:  fmt.Errorf("Liczba wierszy pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy"),
 		},
 		"left divide - singular matrix": {
 			matrix1: pok2.Matrix{
-				{1, 2, 3},
-				{4, 5, 6},
+				{1, 2},
+				{2, 4},
+				{3, 6},
 			},
 			matrix2: pok2.Matrix{
 				{1, 1},
@@ -591,7 +639,7 @@ func TestMatrixLeftDivide(t *testing.T) {
 				{1, 1},
 			},
 			expectedResult: nil,
-			expectedError:  fmt.Errorf("Matrix is singular"),
+			expectedError:  &pok2.RankError{Rank: 1, Cols: 2},
 		},
 		"left divide with ones column": {
 			matrix1: pok2.Matrix{
@@ -658,6 +706,124 @@ func TestMatrixLeftDivide(t *testing.T) {
 	}
 }
 
+func TestMatrixWeightedLeftDivide(t *testing.T) {
+	cases := map[string]struct {
+		matrix1        pok2.Matrix
+		matrix2        pok2.Matrix
+		weights        pok2.Vector
+		expectedResult pok2.Matrix
+		expectedError  error
+	}{
+		"weighted mean": {
+			matrix1:        pok2.Matrix{{1}, {1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}, {4}},
+			weights:        pok2.Vector{1, 1, 2},
+			expectedResult: pok2.Matrix{{2.75}},
+			expectedError:  nil,
+		},
+		"unit weights equal left divide": {
+			matrix1: pok2.Matrix{
+				{1, 1.3},
+				{1, 2.1},
+				{1, 3.7},
+				{1, 4.2},
+			},
+			matrix2: pok2.Matrix{
+				{2.2},
+				{5.8},
+				{10.2},
+				{11.8},
+			},
+			weights: pok2.Vector{1, 1, 1, 1},
+			expectedResult: pok2.Matrix{
+				{-1.5225601452564645},
+				{3.1938266000907847},
+			},
+			expectedError: nil,
+		},
+		"zero weight ignores row": {
+			matrix1: pok2.Matrix{
+				{1, 0},
+				{1, 1},
+				{1, 2},
+			},
+			matrix2: pok2.Matrix{
+				{0, 1},
+				{1, 7},
+				{3, 5},
+			},
+			weights: pok2.Vector{1, 0, 1},
+			expectedResult: pok2.Matrix{
+				{0, 1},
+				{1.5, 2},
+			},
+			expectedError: nil,
+		},
+		"rank deficient after weighting": {
+			matrix1: pok2.Matrix{
+				{1, 0},
+				{1, 1},
+				{1, 2},
+			},
+			matrix2:        pok2.Matrix{{0}, {1}, {3}},
+			weights:        pok2.Vector{2, 0, 0},
+			expectedResult: nil,
+			expectedError:  &pok2.RankError{Rank: 1, Cols: 2},
+		},
+		"wrong number of weights": {
+			matrix1:        pok2.Matrix{{1}, {1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}, {4}},
+			weights:        pok2.Vector{1, 1},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Liczba wag musi być równa liczbie wierszy macierzy"),
+		},
+		"negative weight": {
+			matrix1:        pok2.Matrix{{1}, {1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}, {4}},
+			weights:        pok2.Vector{1, -1, 1},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Wagi muszą być nieujemnymi liczbami skończonymi"),
+		},
+		"NaN weight": {
+			matrix1:        pok2.Matrix{{1}, {1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}, {4}},
+			weights:        pok2.Vector{1, math.NaN(), 1},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Wagi muszą być nieujemnymi liczbami skończonymi"),
+		},
+		"wrong right-hand side rows": {
+			matrix1:        pok2.Matrix{{1}, {1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}},
+			weights:        pok2.Vector{1, 1, 1},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Liczba wierszy pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy"),
+		},
+		"right-hand side rows checked before weights": {
+			matrix1:        pok2.Matrix{{1}, {1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}},
+			weights:        pok2.Vector{1, 1},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Liczba wierszy pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy"),
+		},
+		"right-hand side with more rows": {
+			matrix1:        pok2.Matrix{{1}, {1}},
+			matrix2:        pok2.Matrix{{1}, {2}, {3}},
+			weights:        pok2.Vector{1, 1},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("Liczba wierszy pierwszej macierzy musi być równa liczbie wierszy drugiej macierzy"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, err := c.matrix1.WeightedLeftDivide(c.matrix2, c.weights)
+
+			assert.Equal(t, true, result.IsSimilar(c.expectedResult, 1e-10))
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
 func TestMatrixInverse(t *testing.T) {
 	cases := map[string]struct {
 		matrix         pok2.Matrix
@@ -769,3 +935,211 @@ func TestMatrixIsSimilar(t *testing.T) {
 		})
 	}
 }
+
+func TestMatrixInvertInPlace(t *testing.T) {
+	m := pok2.Matrix{
+		{4, 7},
+		{2, 6},
+	}
+	expected := pok2.Matrix{
+		{0.6, -0.7},
+		{-0.2, 0.4},
+	}
+
+	inverted, err := m.InvertInPlace()
+	assert.Nil(t, err)
+	assert.Equal(t, true, inverted.IsSimilar(expected, 1e-10))
+	assert.Equal(t, true, m.IsSimilar(expected, 1e-10))
+}
+
+func TestMatrixMethodsDoNotModifyReceiver(t *testing.T) {
+	newMatrix := func() pok2.Matrix {
+		// Wiersze mają zapasową pojemność, aby wykryć dopisywanie do współdzielonej tablicy bazowej
+		m := make(pok2.Matrix, 3)
+		for i, row := range [][]float64{{4, 1, 0}, {1, 3, 1}, {0, 1, 2}} {
+			m[i] = make(pok2.Vector, 0, 8)
+			m[i] = append(m[i], row...)
+		}
+		return m
+	}
+	other := pok2.Matrix{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+	}
+
+	cases := map[string]func(m pok2.Matrix){
+		"Dim":        func(m pok2.Matrix) { m.Dim() },
+		"Validate":   func(m pok2.Matrix) { m.Validate() },
+		"Invert":     func(m pok2.Matrix) { m.Invert() },
+		"Log":        func(m pok2.Matrix) { m.Log() },
+		"Exp":        func(m pok2.Matrix) { m.Exp() },
+		"LeftDivide": func(m pok2.Matrix) { m.LeftDivide(other) },
+		"WeightedLeftDivide": func(m pok2.Matrix) {
+			m.WeightedLeftDivide(other, pok2.Vector{1, 2, 3})
+			other.WeightedLeftDivide(m, pok2.Vector{1, 2, 3})
+		},
+		"MultiplyBy":    func(m pok2.Matrix) { m.MultiplyBy(other) },
+		"InsertCol":     func(m pok2.Matrix) { m.InsertCol(1, pok2.Vector{9, 9, 9}) },
+		"Row":           func(m pok2.Matrix) { r, _ := m.Row(0); r[0] = 100 },
+		"Col":           func(m pok2.Matrix) { m.Col(0) },
+		"Transpose":     func(m pok2.Matrix) { m.Transpose() },
+		"IsSimilar":     func(m pok2.Matrix) { m.IsSimilar(other, 1e-10) },
+		"IsEqual":       func(m pok2.Matrix) { m.IsEqual(other) },
+		"Add":           func(m pok2.Matrix) { m.Add(other) },
+		"Subtract":      func(m pok2.Matrix) { m.Subtract(other) },
+		"LU":            func(m pok2.Matrix) { m.LU() },
+		"QR":            func(m pok2.Matrix) { m.QR() },
+		"Cholesky":      func(m pok2.Matrix) { m.Cholesky() },
+		"SVD":           func(m pok2.Matrix) { m.SVD() },
+		"PseudoInverse": func(m pok2.Matrix) { m.PseudoInverse(0) },
+		"Rank":          func(m pok2.Matrix) { m.Rank(0) },
+		"Condition":     func(m pok2.Matrix) { m.ConditionNumber() },
+		"EigenSym":      func(m pok2.Matrix) { m.EigenSym() },
+		"Eigen":         func(m pok2.Matrix) { m.Eigen() },
+		"Det":           func(m pok2.Matrix) { m.Det() },
+		"Trace":         func(m pok2.Matrix) { m.Trace() },
+		"Norms": func(m pok2.Matrix) {
+			m.Norm1()
+			m.NormInf()
+			m.NormFrobenius()
+			m.NormSpectral()
+		},
+		"SolveLeastSquares": func(m pok2.Matrix) { m.SolveLeastSquares(other) },
+		"factor solvers as right-hand side": func(m pok2.Matrix) {
+			lu, _ := other.LU()
+			lu.Solve(m)
+			qr, _ := other.QR()
+			qr.Solve(m)
+			chol, _ := pok2.Matrix{{2, 0, 0}, {0, 2, 0}, {0, 0, 2}}.Cholesky()
+			chol.Solve(m)
+		},
+	}
+
+	for name, call := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := newMatrix()
+			call(m)
+			assert.Equal(t, true, m.IsEqual(newMatrix()))
+		})
+	}
+}
+
+func TestMatrixValidate(t *testing.T) {
+	cases := map[string]struct {
+		matrix        pok2.Matrix
+		expectedError error
+	}{
+		"valid matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{3, 4},
+			},
+			expectedError: nil,
+		},
+		"nil matrix": {
+			matrix:        nil,
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"matrix without rows": {
+			matrix:        pok2.Matrix{},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"matrix without columns": {
+			matrix:        pok2.Matrix{{}, {}},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"ragged matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{3, 4},
+				{5},
+			},
+			expectedError: &pok2.RaggedError{Row: 2, Len: 1, Cols: 2},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expectedError, c.matrix.Validate())
+		})
+	}
+}
+
+func TestMatrixMethodsRejectInvalidInput(t *testing.T) {
+	valid := pok2.Matrix{
+		{1, 2},
+		{3, 4},
+	}
+	inputs := map[string]pok2.Matrix{
+		"nil":     nil,
+		"empty":   {},
+		"ragged":  {{1, 2}, {3}},
+		"ragged2": {{1}, {2, 3}},
+	}
+
+	calls := map[string]func(m pok2.Matrix) error{
+		"Invert":        func(m pok2.Matrix) error { _, err := m.Invert(); return err },
+		"InvertInPlace": func(m pok2.Matrix) error { _, err := m.InvertInPlace(); return err },
+		"LeftDivide":    func(m pok2.Matrix) error { _, err := m.LeftDivide(valid); return err },
+		"LeftDivideRHS": func(m pok2.Matrix) error { _, err := valid.LeftDivide(m); return err },
+		"WeightedLeftDivide": func(m pok2.Matrix) error {
+			_, err := m.WeightedLeftDivide(valid, pok2.Vector{1, 1})
+			return err
+		},
+		"WeightedLeftDivideRHS": func(m pok2.Matrix) error {
+			_, err := valid.WeightedLeftDivide(m, pok2.Vector{1, 1})
+			return err
+		},
+		"MultiplyBy":    func(m pok2.Matrix) error { _, err := m.MultiplyBy(valid); return err },
+		"MultiplyByRHS": func(m pok2.Matrix) error { _, err := valid.MultiplyBy(m); return err },
+		"InsertCol":     func(m pok2.Matrix) error { _, err := m.InsertCol(1, pok2.Vector{0, 0}); return err },
+		"Col":           func(m pok2.Matrix) error { _, err := m.Col(1); return err },
+		"Transpose":     func(m pok2.Matrix) error { _, err := m.Transpose(); return err },
+		"Add":           func(m pok2.Matrix) error { _, err := m.Add(m); return err },
+		"Subtract":      func(m pok2.Matrix) error { _, err := m.Subtract(m); return err },
+		"LU":            func(m pok2.Matrix) error { _, err := m.LU(); return err },
+		"QR":            func(m pok2.Matrix) error { _, err := m.QR(); return err },
+		"Cholesky":      func(m pok2.Matrix) error { _, err := m.Cholesky(); return err },
+		"SVD":           func(m pok2.Matrix) error { _, err := m.SVD(); return err },
+		"PseudoInverse": func(m pok2.Matrix) error { _, err := m.PseudoInverse(0); return err },
+		"Rank":          func(m pok2.Matrix) error { _, err := m.Rank(0); return err },
+		"Condition":     func(m pok2.Matrix) error { _, err := m.ConditionNumber(); return err },
+		"EigenSym":      func(m pok2.Matrix) error { _, _, err := m.EigenSym(); return err },
+		"Eigen":         func(m pok2.Matrix) error { _, err := m.Eigen(); return err },
+		"Det":           func(m pok2.Matrix) error { _, err := m.Det(); return err },
+		"Trace":         func(m pok2.Matrix) error { _, err := m.Trace(); return err },
+		"Norm1":         func(m pok2.Matrix) error { _, err := m.Norm1(); return err },
+		"NormInf":       func(m pok2.Matrix) error { _, err := m.NormInf(); return err },
+		"NormFrobenius": func(m pok2.Matrix) error { _, err := m.NormFrobenius(); return err },
+		"NormSpectral":  func(m pok2.Matrix) error { _, err := m.NormSpectral(); return err },
+		"Solve":         func(m pok2.Matrix) error { _, err := m.SolveLeastSquares(valid); return err },
+		"LUSolveRHS": func(m pok2.Matrix) error {
+			lu, _ := valid.LU()
+			_, err := lu.Solve(m)
+			return err
+		},
+	}
+
+	for inputName, input := range inputs {
+		for callName, call := range calls {
+			t.Run(callName+" with "+inputName+" matrix", func(t *testing.T) {
+				var err error
+				assert.NotPanics(t, func() { err = call(input) })
+				assert.NotNil(t, err)
+			})
+		}
+
+		t.Run("non-error methods with "+inputName+" matrix", func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				input.Dim()
+				input.Row(0)
+				input.Log()
+				input.Exp()
+				input.IsEqual(valid)
+				input.IsSimilar(valid, 1e-10)
+				valid.IsSimilar(input, 1e-10)
+			})
+		})
+	}
+}
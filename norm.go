@@ -0,0 +1,105 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+)
+
+// Det zwraca wyznacznik macierzy obliczony z rozkładu LU oraz błąd (jeśli istnieje).
+func (m Matrix) Det() (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if !m.isSquare() {
+		return 0, fmt.Errorf("Nie można obliczyć wyznacznika macierzy niekwadratowej")
+	}
+
+	lu, err := m.LU()
+	if err != nil {
+		return 0, err
+	}
+	return lu.Det(), nil
+}
+
+// Trace zwraca ślad macierzy, czyli sumę elementów na przekątnej, oraz błąd (jeśli istnieje).
+func (m Matrix) Trace() (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if !m.isSquare() {
+		return 0, fmt.Errorf("Nie można obliczyć śladu macierzy niekwadratowej")
+	}
+
+	var trace float64
+	for i := range m {
+		trace += m[i][i]
+	}
+	return trace, nil
+}
+
+// Norm1 zwraca normę kolumnową macierzy, czyli największą sumę wartości bezwzględnych w kolumnie, oraz błąd (jeśli istnieje).
+// Podobnie jak pozostałe normy zwraca błąd dla macierzy pustej lub poszarpanej.
+func (m Matrix) Norm1() (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	_, cols := m.Dim()
+	sums := make(Vector, cols)
+	for i := range m {
+		for j := range m[i] {
+			sums[j] += math.Abs(m[i][j])
+		}
+	}
+
+	var norm float64
+	for _, s := range sums {
+		norm = math.Max(norm, s)
+	}
+	return norm, nil
+}
+
+// NormInf zwraca normę wierszową macierzy, czyli największą sumę wartości bezwzględnych w wierszu, oraz błąd (jeśli istnieje).
+func (m Matrix) NormInf() (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	var norm float64
+	for i := range m {
+		var s float64
+		for j := range m[i] {
+			s += math.Abs(m[i][j])
+		}
+		norm = math.Max(norm, s)
+	}
+	return norm, nil
+}
+
+// NormFrobenius zwraca normę Frobeniusa macierzy, czyli pierwiastek z sumy kwadratów wszystkich elementów, oraz błąd (jeśli istnieje).
+func (m Matrix) NormFrobenius() (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	var norm float64
+	for i := range m {
+		for j := range m[i] {
+			norm = math.Hypot(norm, m[i][j])
+		}
+	}
+	return norm, nil
+}
+
+// NormSpectral zwraca normę spektralną macierzy, czyli jej największą wartość osobliwą, oraz błąd (jeśli istnieje).
+// Norma wyznaczana jest z rozkładu SVD; metoda potęgowa może zbiec do mniejszej wartości osobliwej,
+// gdy wektor startowy jest ortogonalny do pierwszego prawego wektora osobliwego.
+func (m Matrix) NormSpectral() (float64, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return 0, err
+	}
+	return svd.Sigma[0], nil
+}
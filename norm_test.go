@@ -0,0 +1,190 @@
+package pok2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixDet(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedResult float64
+		expectedError  error
+	}{
+		"2x2 determinant": {
+			matrix: pok2.Matrix{
+				{4, 7},
+				{2, 6},
+			},
+			expectedResult: 10,
+			expectedError:  nil,
+		},
+		"3x3 determinant": {
+			matrix: pok2.Matrix{
+				{3, 0, 2},
+				{2, 0, -2},
+				{0, 1, 1},
+			},
+			expectedResult: 10,
+			expectedError:  nil,
+		},
+		"singular matrix determinant": {
+			matrix: pok2.Matrix{
+				{2, 4},
+				{6, 12},
+			},
+			expectedResult: 0,
+			expectedError:  nil,
+		},
+		"non-square matrix determinant": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+			},
+			expectedResult: 0,
+			expectedError:  fmt.Errorf("Nie można obliczyć wyznacznika macierzy niekwadratowej"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			det, err := c.matrix.Det()
+			assert.InDelta(t, c.expectedResult, det, 1e-10)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestMatrixTrace(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedResult float64
+		expectedError  error
+	}{
+		"basic trace": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{3, 4},
+			},
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+		"non-square matrix trace": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+			},
+			expectedResult: 0,
+			expectedError:  fmt.Errorf("Nie można obliczyć śladu macierzy niekwadratowej"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			trace, err := c.matrix.Trace()
+			assert.Equal(t, c.expectedResult, trace)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
+
+func TestMatrixNorms(t *testing.T) {
+	cases := map[string]struct {
+		matrix            pok2.Matrix
+		expectedNorm1     float64
+		expectedNormInf   float64
+		expectedFrobenius float64
+		expectedSpectral  float64
+		expectedError     error
+	}{
+		"square matrix norms": {
+			matrix: pok2.Matrix{
+				{1, -2},
+				{-3, 4},
+			},
+			expectedNorm1:     6,
+			expectedNormInf:   7,
+			expectedFrobenius: math.Sqrt(30),
+			expectedSpectral:  math.Sqrt(15 + math.Sqrt(221)),
+		},
+		"rectangular matrix norms": {
+			matrix: pok2.Matrix{
+				{3, 2, 2},
+				{2, 3, -2},
+			},
+			expectedNorm1:     5,
+			expectedNormInf:   7,
+			expectedFrobenius: math.Sqrt(34),
+			expectedSpectral:  5,
+		},
+		"ones vector in null space": {
+			matrix: pok2.Matrix{
+				{1, -1},
+			},
+			expectedNorm1:     1,
+			expectedNormInf:   2,
+			expectedFrobenius: math.Sqrt2,
+			expectedSpectral:  math.Sqrt2,
+		},
+		"rank one matrix with ones vector in null space": {
+			matrix: pok2.Matrix{
+				{1, -1},
+				{2, -2},
+			},
+			expectedNorm1:     3,
+			expectedNormInf:   4,
+			expectedFrobenius: math.Sqrt(10),
+			expectedSpectral:  math.Sqrt(10),
+		},
+		"largest row orthogonal to top singular vector": {
+			matrix: pok2.Matrix{
+				{2, 0},
+				{0, 1.5},
+				{0, 1.5},
+			},
+			expectedNorm1:     3,
+			expectedNormInf:   2,
+			expectedFrobenius: math.Sqrt(8.5),
+			expectedSpectral:  math.Sqrt(4.5),
+		},
+		"zero matrix norms": {
+			matrix: pok2.Matrix{
+				{0, 0},
+				{0, 0},
+			},
+		},
+		"nil matrix norms": {
+			matrix:        nil,
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"ragged matrix norms": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{3},
+			},
+			expectedError: &pok2.RaggedError{Row: 1, Len: 1, Cols: 2},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			norm1, err := c.matrix.Norm1()
+			assert.Equal(t, c.expectedNorm1, norm1)
+			assert.Equal(t, c.expectedError, err)
+
+			normInf, err := c.matrix.NormInf()
+			assert.Equal(t, c.expectedNormInf, normInf)
+			assert.Equal(t, c.expectedError, err)
+
+			frobenius, err := c.matrix.NormFrobenius()
+			assert.InDelta(t, c.expectedFrobenius, frobenius, 1e-12)
+			assert.Equal(t, c.expectedError, err)
+
+			spectral, err := c.matrix.NormSpectral()
+			assert.InDelta(t, c.expectedSpectral, spectral, 1e-8)
+			assert.Equal(t, c.expectedError, err)
+		})
+	}
+}
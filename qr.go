@@ -0,0 +1,188 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+)
+
+// QR przechowuje rozkład macierzy A (m x n) w postaci A = Q*R, uzyskany odbiciami Householdera.
+// Q ma ortonormalne kolumny (m x k), a R jest macierzą górnotrapezową (k x n), gdzie k = min(m, n).
+type QR struct {
+	Q Matrix
+	R Matrix
+}
+
+// RankError jest zwracany, gdy macierz nie ma pełnego rzędu kolumnowego, więc zadanie najmniejszych kwadratów nie ma jednoznacznego rozwiązania.
+type RankError struct {
+	Rank int
+	Cols int
+}
+
+func (e *RankError) Error() string {
+	return fmt.Sprintf("Macierz ma niepełny rząd kolumnowy (rząd %d, kolumny %d)", e.Rank, e.Cols)
+}
+
+// QR zwraca rozkład QR macierzy oraz błąd (jeśli istnieje).
+func (m Matrix) QR() (*QR, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	rows, cols := m.Dim()
+	k := rows
+	if cols < k {
+		k = cols
+	}
+
+	a := m.copy()
+	reflectors := make([]Vector, k)
+
+	for c := 0; c < k; c++ {
+		var norm float64
+		for i := c; i < rows; i++ {
+			norm = math.Hypot(norm, a[i][c])
+		}
+		if norm == 0 {
+			continue
+		}
+
+		// Znak dobieramy tak, aby uniknąć odejmowania bliskich sobie liczb
+		alpha := -norm
+		if a[c][c] < 0 {
+			alpha = norm
+		}
+
+		v := make(Vector, rows-c)
+		for i := c; i < rows; i++ {
+			v[i-c] = a[i][c]
+		}
+		v[0] -= alpha
+
+		vv, _ := v.Dot(v)
+		if vv == 0 {
+			continue
+		}
+
+		applyReflector(a, v, vv, c, c)
+		reflectors[c] = v
+	}
+
+	r := make(Matrix, k)
+	for i := 0; i < k; i++ {
+		r[i] = make(Vector, cols)
+		for j := i; j < cols; j++ {
+			r[i][j] = a[i][j]
+		}
+	}
+
+	// Q = H(0) * H(1) * ... * H(k-1) zastosowane do pierwszych k kolumn macierzy jednostkowej
+	q := make(Matrix, rows)
+	for i := range q {
+		q[i] = make(Vector, k)
+		if i < k {
+			q[i][i] = 1
+		}
+	}
+	for c := k - 1; c >= 0; c-- {
+		if v := reflectors[c]; v != nil {
+			vv, _ := v.Dot(v)
+			applyReflector(q, v, vv, c, 0)
+		}
+	}
+
+	return &QR{Q: q, R: r}, nil
+}
+
+// applyReflector mnoży od lewej podmacierz a[r:, c:] przez odbicie I - 2*v*v^T/(v^T*v).
+func applyReflector(a Matrix, v Vector, vv float64, r, c int) {
+	for j := c; j < len(a[0]); j++ {
+		var s float64
+		for i := range v {
+			s += v[i] * a[r+i][j]
+		}
+		s = 2 * s / vv
+		for i := range v {
+			a[r+i][j] -= s * v[i]
+		}
+	}
+}
+
+// Rank zwraca rząd macierzy oszacowany na podstawie przekątnej R.
+func (f *QR) Rank() int {
+	rows, _ := f.Q.Dim()
+	_, cols := f.R.Dim()
+	if cols > rows {
+		rows = cols
+	}
+	tol := float64(rows) * eps * f.maxDiag()
+
+	rank := 0
+	for i := range f.R {
+		if math.Abs(f.R[i][i]) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+func (f *QR) maxDiag() float64 {
+	var d float64
+	for i := range f.R {
+		d = math.Max(d, math.Abs(f.R[i][i]))
+	}
+	return d
+}
+
+// Solve otrzymuje macierz prawych stron B.
+// Zwraca rozwiązanie X minimalizujące ||A * X - B|| w sensie najmniejszych kwadratów oraz błąd (jeśli istnieje).
+// Jeśli A nie ma pełnego rzędu kolumnowego, zwracany jest błąd typu *RankError.
+func (f *QR) Solve(b Matrix) (Matrix, error) {
+	rows, k := f.Q.Dim()
+	_, cols := f.R.Dim()
+
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	if bRows, _ := b.Dim(); bRows != rows {
+		return nil, fmt.Errorf("Liczba wierszy prawej strony musi być równa liczbie wierszy macierzy")
+	}
+
+	if rank := f.Rank(); rank < cols {
+		return nil, &RankError{Rank: rank, Cols: cols}
+	}
+
+	qT, err := f.Q.Transpose()
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := qT.MultiplyBy(b)
+	if err != nil {
+		return nil, err
+	}
+
+	// R * X = Q^T * B
+	_, bCols := b.Dim()
+	for c := 0; c < bCols; c++ {
+		for i := k - 1; i >= 0; i-- {
+			for j := i + 1; j < cols; j++ {
+				x[i][c] -= f.R[i][j] * x[j][c]
+			}
+			x[i][c] /= f.R[i][i]
+		}
+	}
+
+	return x, nil
+}
+
+// SolveLeastSquares otrzymuje macierz prawych stron B.
+// Rozwiązuje nadokreślony układ A * X = B metodą najmniejszych kwadratów z użyciem rozkładu QR,
+// bez tworzenia macierzy A^T * A. Zwraca X oraz błąd (jeśli istnieje).
+func (m Matrix) SolveLeastSquares(b Matrix) (Matrix, error) {
+	qr, err := m.QR()
+	if err != nil {
+		return nil, err
+	}
+	return qr.Solve(b)
+}
@@ -0,0 +1,139 @@
+package pok2_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixQR(t *testing.T) {
+	cases := map[string]struct {
+		matrix        pok2.Matrix
+		expectedError error
+	}{
+		"square qr decomposition": {
+			matrix: pok2.Matrix{
+				{12, -51, 4},
+				{6, 167, -68},
+				{-4, 24, -41},
+			},
+			expectedError: nil,
+		},
+		"tall qr decomposition": {
+			matrix: pok2.Matrix{
+				{1, 1.3},
+				{1, 2.1},
+				{1, 3.7},
+				{1, 4.2},
+			},
+			expectedError: nil,
+		},
+		"wide qr decomposition": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+				{4, 5, 6},
+			},
+			expectedError: nil,
+		},
+		"qr decomposition of empty matrix": {
+			matrix:        pok2.Matrix{},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			qr, err := c.matrix.QR()
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			product, _ := qr.Q.MultiplyBy(qr.R)
+			assert.Equal(t, true, product.IsSimilar(c.matrix, 1e-10))
+
+			qT, _ := qr.Q.Transpose()
+			qtq, _ := qT.MultiplyBy(qr.Q)
+			_, k := qr.Q.Dim()
+			id := make(pok2.Matrix, k)
+			for i := range id {
+				id[i] = make(pok2.Vector, k)
+				id[i][i] = 1
+			}
+			assert.Equal(t, true, qtq.IsSimilar(id, 1e-10))
+		})
+	}
+}
+
+func TestMatrixSolveLeastSquares(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		rhs            pok2.Matrix
+		expectedResult pok2.Matrix
+		expectedError  error
+	}{
+		"least squares line fit": {
+			matrix: pok2.Matrix{
+				{1, 1.3},
+				{1, 2.1},
+				{1, 3.7},
+				{1, 4.2},
+			},
+			rhs: pok2.Matrix{
+				{2.2},
+				{5.8},
+				{10.2},
+				{11.8},
+			},
+			expectedResult: pok2.Matrix{
+				{-1.5225601452564645},
+				{3.1938266000907847},
+			},
+			expectedError: nil,
+		},
+		"near-collinear columns": {
+			matrix: pok2.Matrix{
+				{1, 1},
+				{1, 1 + 1e-5},
+				{1, 1 + 2e-5},
+			},
+			rhs: pok2.Matrix{
+				{1},
+				{2},
+				{3},
+			},
+			expectedResult: pok2.Matrix{
+				{-1e5 + 1},
+				{1e5},
+			},
+			expectedError: nil,
+		},
+		"collinear columns": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{2, 4},
+				{3, 6},
+			},
+			rhs: pok2.Matrix{
+				{1},
+				{2},
+				{3},
+			},
+			expectedResult: nil,
+			expectedError:  &pok2.RankError{Rank: 1, Cols: 2},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			solved, err := c.matrix.SolveLeastSquares(c.rhs)
+			assert.Equal(t, true, solved.IsSimilar(c.expectedResult, 1e-4))
+			assert.Equal(t, c.expectedError, err)
+
+			var rankErr *pok2.RankError
+			assert.Equal(t, c.expectedError != nil, errors.As(err, &rankErr))
+		})
+	}
+}
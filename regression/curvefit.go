@@ -0,0 +1,276 @@
+package regression
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/53jk1/pok2"
+)
+
+const (
+	// maxCurveFitIterations ogranicza liczbę wyznaczeń jakobianu w CurveFit
+	maxCurveFitIterations = 500
+	// curveFitTolerance to względna zmiana sumy kwadratów reszt lub parametrów, przy której uznajemy zbieżność
+	curveFitTolerance = 1e-12
+	// initialDamping, minDamping i maxDamping określają zakres parametru tłumienia mu metody Levenberga-Marquardta
+	initialDamping = 1e-3
+	minDamping     = 1e-15
+	maxDamping     = 1e15
+)
+
+// Model zwraca wartość dopasowywanej funkcji w punkcie x dla parametrów p
+type Model func(x float64, p pok2.Vector) float64
+
+// Jacobian zwraca pochodne cząstkowe modelu po kolejnych parametrach w punkcie x
+type Jacobian func(x float64, p pok2.Vector) pok2.Vector
+
+// CurveFitResult przechowuje wynik nieliniowego dopasowania metodą najmniejszych kwadratów.
+type CurveFitResult struct {
+	Params pok2.Vector
+	// Covariance to s^2 * (J^T J)^-1, gdzie s^2 = RSS/(n - m); NaN, jeśli punktów jest tyle co parametrów,
+	// i +Inf, jeśli J w rozwiązaniu nie ma pełnego rzędu kolumnowego (parametry nie są identyfikowalne)
+	Covariance pok2.Matrix
+	StdErrors  pok2.Vector
+	// Residuals[i] = y[i] - model(x[i], Params)
+	Residuals pok2.Vector
+	RSS       float64
+
+	// Iterations to liczba wyznaczeń jakobianu, a Converged informuje, czy spełniono kryterium zbieżności
+	// przed osiągnięciem limitu iteracji
+	Iterations int
+	Converged  bool
+}
+
+// CurveFit dopasowuje parametry modelu do punktów (x[i], y[i]) metodą Levenberga-Marquardta,
+// rozpoczynając od p0. Jakobian wyznaczany jest numerycznie ilorazami różnicowymi centralnymi.
+// W przeciwieństwie do dopasowania zlinearyzowanego (np. regresji log(y) dla modelu wykładniczego)
+// minimalizuje sumę kwadratów reszt w oryginalnej skali y.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, punktów jest mniej niż parametrów lub model nie jest
+// skończony dla p0. Brak zbieżności nie jest błędem, lecz jest sygnalizowany przez Converged,
+// a pojedyncza macierz J w rozwiązaniu daje nieskończone Covariance i StdErrors.
+func CurveFit(model Model, x, y, p0 pok2.Vector) (*CurveFitResult, error) {
+	return CurveFitWithJacobian(model, nil, x, y, p0)
+}
+
+// CurveFitWithJacobian działa jak CurveFit, ale używa jakobianu jac podanego przez użytkownika.
+// Dla jac równego nil jakobian wyznaczany jest numerycznie.
+func CurveFitWithJacobian(model Model, jac Jacobian, x, y, p0 pok2.Vector) (*CurveFitResult, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("Rozmiary X i Y nie pasują")
+	}
+	if len(p0) == 0 {
+		return nil, fmt.Errorf("Model musi mieć co najmniej jeden parametr")
+	}
+	if len(x) < len(p0) {
+		return nil, fmt.Errorf("Liczba punktów (%d) jest mniejsza niż liczba parametrów (%d)", len(x), len(p0))
+	}
+
+	f := &curveFit{model: model, jac: jac, x: x, y: y}
+
+	p := append(pok2.Vector{}, p0...)
+	r, cost := f.residuals(p)
+	if math.IsInf(cost, 0) {
+		return nil, fmt.Errorf("Model nie zwraca skończonych wartości dla parametrów początkowych")
+	}
+
+	m := len(p)
+	scale := make(pok2.Vector, m)
+	mu := initialDamping
+	converged := false
+	its := 0
+
+	for its < maxCurveFitIterations && !converged {
+		if cost == 0 {
+			converged = true
+			break
+		}
+		its++
+
+		j, err := f.jacobian(p)
+		if err != nil {
+			return nil, err
+		}
+
+		// Skalowanie Marquardta diag(J^T J), zachowujące największe dotąd wartości
+		for k := 0; k < m; k++ {
+			var d float64
+			for i := range j {
+				d += j[i][k] * j[i][k]
+			}
+			scale[k] = math.Max(scale[k], d)
+			if scale[k] == 0 {
+				scale[k] = 1
+			}
+		}
+
+		for {
+			step, err := dampedStep(j, r, scale, mu)
+			if err != nil {
+				return nil, err
+			}
+
+			next, err := p.Add(step)
+			if err != nil {
+				return nil, err
+			}
+			nextR, nextCost := f.residuals(next)
+
+			if nextCost <= cost {
+				converged = cost-nextCost <= curveFitTolerance*cost ||
+					norm(step) <= curveFitTolerance*(norm(p)+curveFitTolerance)
+				p, r, cost = next, nextR, nextCost
+				mu = math.Max(mu/10, minDamping)
+				break
+			}
+
+			// Nawet bardzo krótki krok nie zmniejsza sumy kwadratów; kończymy bez potwierdzonej zbieżności
+			mu *= 10
+			if mu > maxDamping {
+				break
+			}
+		}
+	}
+
+	res := &CurveFitResult{
+		Params:     p,
+		Residuals:  r,
+		RSS:        cost,
+		Iterations: its,
+		Converged:  converged,
+	}
+
+	j, err := f.jacobian(p)
+	if err != nil {
+		return nil, err
+	}
+	res.Covariance = covariance(j, cost, len(x)-m)
+	res.StdErrors = make(pok2.Vector, m)
+	for k := range res.StdErrors {
+		res.StdErrors[k] = math.Sqrt(res.Covariance[k][k])
+	}
+	return res, nil
+}
+
+// curveFit przechowuje dane dopasowania wspólne dla kolejnych iteracji
+type curveFit struct {
+	model Model
+	jac   Jacobian
+	x, y  pok2.Vector
+}
+
+// residuals zwraca reszty i ich sumę kwadratów; dla nieskończonych wartości modelu suma jest +Inf
+func (f *curveFit) residuals(p pok2.Vector) (pok2.Vector, float64) {
+	r := make(pok2.Vector, len(f.x))
+	var cost float64
+	for i := range f.x {
+		r[i] = f.y[i] - f.model(f.x[i], p)
+		cost += r[i] * r[i]
+	}
+	if math.IsNaN(cost) || math.IsInf(cost, 0) {
+		return r, math.Inf(1)
+	}
+	return r, cost
+}
+
+// jacobian zwraca macierz J[i][k] = d model(x[i], p) / d p[k]
+func (f *curveFit) jacobian(p pok2.Vector) (pok2.Matrix, error) {
+	j := make(pok2.Matrix, len(f.x))
+
+	if f.jac != nil {
+		for i := range f.x {
+			d := f.jac(f.x[i], p)
+			if len(d) != len(p) {
+				return nil, fmt.Errorf("Jakobian ma %d elementów, a model %d parametrów", len(d), len(p))
+			}
+			j[i] = append(pok2.Vector{}, d...)
+		}
+		return j, nil
+	}
+
+	for i := range j {
+		j[i] = make(pok2.Vector, len(p))
+	}
+
+	// Krok różnicowy rzędu eps^(1/3) minimalizuje błąd ilorazu centralnego
+	h0 := math.Cbrt(2.220446049250313e-16)
+	q := append(pok2.Vector{}, p...)
+	for k := range p {
+		h := h0 * math.Max(math.Abs(p[k]), 1)
+		for i := range f.x {
+			q[k] = p[k] + h
+			fp := f.model(f.x[i], q)
+			q[k] = p[k] - h
+			fm := f.model(f.x[i], q)
+			j[i][k] = (fp - fm) / (2 * h)
+		}
+		q[k] = p[k]
+	}
+	return j, nil
+}
+
+// dampedStep rozwiązuje (J^T J + mu * D) * step = J^T r jako rozszerzony układ najmniejszych kwadratów
+// [J; sqrt(mu * D)] * step = [r; 0], bez tworzenia J^T J.
+func dampedStep(j pok2.Matrix, r, scale pok2.Vector, mu float64) (pok2.Vector, error) {
+	n, m := j.Dim()
+
+	a := make(pok2.Matrix, n+m)
+	b := make(pok2.Matrix, n+m)
+	for i := 0; i < n; i++ {
+		a[i] = j[i]
+		b[i] = pok2.Vector{r[i]}
+	}
+	for k := 0; k < m; k++ {
+		a[n+k] = make(pok2.Vector, m)
+		a[n+k][k] = math.Sqrt(mu * scale[k])
+		b[n+k] = pok2.Vector{0}
+	}
+
+	s, err := a.LeftDivide(b)
+	if err != nil {
+		return nil, err
+	}
+
+	step := make(pok2.Vector, m)
+	for k := range step {
+		step[k] = s[k][0]
+	}
+	return step, nil
+}
+
+// covariance zwraca macierz kowariancji parametrów s^2 * (J^T J)^-1, gdzie s^2 = rss/df.
+// (J^T J)^-1 wyznaczana jest z czynnika R rozkładu QR macierzy J, bez tworzenia J^T J.
+func covariance(j pok2.Matrix, rss float64, df int) pok2.Matrix {
+	_, m := j.Dim()
+
+	// QR nie zwraca błędu, bo J jest niepusta i ma po m elementów w każdym wierszu
+	qr, _ := j.QR()
+
+	if qr.Rank() < m {
+		cov := make(pok2.Matrix, m)
+		for k := range cov {
+			cov[k] = make(pok2.Vector, m)
+			for l := range cov[k] {
+				cov[k][l] = math.Inf(1)
+			}
+		}
+		return cov
+	}
+
+	cov := unscaledCovariance(qr.R)
+
+	s2 := math.NaN()
+	if df > 0 {
+		s2 = rss / float64(df)
+	}
+	for k := range cov {
+		for l := range cov[k] {
+			cov[k][l] *= s2
+		}
+	}
+	return cov
+}
+
+func norm(v pok2.Vector) float64 {
+	dot, _ := v.Dot(v)
+	return math.Sqrt(dot)
+}
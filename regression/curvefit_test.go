@@ -0,0 +1,229 @@
+package regression_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/regression"
+	"github.com/stretchr/testify/assert"
+)
+
+func exponential(x float64, p pok2.Vector) float64 {
+	return p[0] * math.Exp(p[1]*x)
+}
+
+func logistic(x float64, p pok2.Vector) float64 {
+	return p[0] / (1 + math.Exp(-p[1]*(x-p[2])))
+}
+
+func logisticJacobian(x float64, p pok2.Vector) pok2.Vector {
+	e := math.Exp(-p[1] * (x - p[2]))
+	d := (1 + e) * (1 + e)
+	return pok2.Vector{1 / (1 + e), p[0] * (x - p[2]) * e / d, -p[0] * p[1] * e / d}
+}
+
+func line(x float64, p pok2.Vector) float64 {
+	return p[0] + p[1]*x
+}
+
+func sample(model regression.Model, x pok2.Vector, p pok2.Vector) pok2.Vector {
+	y := make(pok2.Vector, len(x))
+	for i := range x {
+		y[i] = model(x[i], p)
+	}
+	return y
+}
+
+func TestCurveFit(t *testing.T) {
+	cases := map[string]struct {
+		model              regression.Model
+		jacobian           regression.Jacobian
+		x                  pok2.Vector
+		y                  pok2.Vector
+		p0                 pok2.Vector
+		expectedParams     pok2.Vector
+		expectedCovariance pok2.Matrix
+		expectedError      error
+	}{
+		"exponential without noise": {
+			model:          exponential,
+			x:              pok2.Vector{0, 1, 2, 3, 4, 5},
+			y:              sample(exponential, pok2.Vector{0, 1, 2, 3, 4, 5}, pok2.Vector{2, 0.5}),
+			p0:             pok2.Vector{1, 0.1},
+			expectedParams: pok2.Vector{2, 0.5},
+		},
+		"logistic with numeric jacobian": {
+			model:          logistic,
+			x:              pok2.Vector{-4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6},
+			y:              sample(logistic, pok2.Vector{-4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6}, pok2.Vector{10, 1.5, 1}),
+			p0:             pok2.Vector{5, 1, 0},
+			expectedParams: pok2.Vector{10, 1.5, 1},
+		},
+		"logistic with user jacobian": {
+			model:          logistic,
+			jacobian:       logisticJacobian,
+			x:              pok2.Vector{-4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6},
+			y:              sample(logistic, pok2.Vector{-4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6}, pok2.Vector{10, 1.5, 1}),
+			p0:             pok2.Vector{5, 1, 0},
+			expectedParams: pok2.Vector{10, 1.5, 1},
+		},
+		"linear model matches least squares": {
+			model:              line,
+			x:                  pok2.Vector{1, 2, 3, 4, 5},
+			y:                  pok2.Vector{2, 4, 5, 4, 5},
+			p0:                 pok2.Vector{0, 0},
+			expectedParams:     pok2.Vector{2.2, 0.6},
+			expectedCovariance: pok2.Matrix{{0.88, -0.24}, {-0.24, 0.08}},
+		},
+		"wrong x and y size": {
+			model:         line,
+			x:             pok2.Vector{1, 2, 3},
+			y:             pok2.Vector{1, 2},
+			p0:            pok2.Vector{0, 0},
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"no parameters": {
+			model:         line,
+			x:             pok2.Vector{1, 2, 3},
+			y:             pok2.Vector{1, 2, 3},
+			p0:            pok2.Vector{},
+			expectedError: fmt.Errorf("Model musi mieć co najmniej jeden parametr"),
+		},
+		"too few points": {
+			model:         logistic,
+			x:             pok2.Vector{1, 2},
+			y:             pok2.Vector{1, 2},
+			p0:            pok2.Vector{1, 1, 1},
+			expectedError: fmt.Errorf("Liczba punktów (2) jest mniejsza niż liczba parametrów (3)"),
+		},
+		"model not finite at start": {
+			model:         exponential,
+			x:             pok2.Vector{1, 2, 1000},
+			y:             pok2.Vector{1, 2, 3},
+			p0:            pok2.Vector{1, 1},
+			expectedError: fmt.Errorf("Model nie zwraca skończonych wartości dla parametrów początkowych"),
+		},
+		"wrong jacobian size": {
+			model:         line,
+			jacobian:      func(x float64, p pok2.Vector) pok2.Vector { return pok2.Vector{1} },
+			x:             pok2.Vector{1, 2, 3},
+			y:             pok2.Vector{1, 2, 3},
+			p0:            pok2.Vector{0, 0},
+			expectedError: fmt.Errorf("Jakobian ma 1 elementów, a model 2 parametrów"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := regression.CurveFitWithJacobian(c.model, c.jacobian, c.x, c.y, c.p0)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				assert.Nil(t, r)
+				return
+			}
+
+			assert.True(t, r.Converged)
+			assert.True(t, c.expectedParams.IsSimilar(r.Params, 1e-7))
+			if c.expectedCovariance != nil {
+				assert.True(t, c.expectedCovariance.IsSimilar(r.Covariance, 1e-7))
+			}
+			for k := range r.StdErrors {
+				assert.InDelta(t, math.Sqrt(r.Covariance[k][k]), r.StdErrors[k], 1e-12)
+			}
+
+			rss := 0.0
+			for i := range c.x {
+				assert.InDelta(t, c.y[i]-c.model(c.x[i], r.Params), r.Residuals[i], 1e-12)
+				rss += r.Residuals[i] * r.Residuals[i]
+			}
+			assert.InDelta(t, rss, r.RSS, 1e-12)
+		})
+	}
+}
+
+func TestCurveFitBeatsLogLinearization(t *testing.T) {
+	x := pok2.Vector{0, 1, 2, 3, 4, 5, 6}
+	y := pok2.Vector{2.3, 2.9, 5.1, 8.4, 14.9, 23.6, 41.2}
+
+	// Regresja log(y) = log(a) + b*x minimalizuje błędy względne, a nie reszty y
+	logY := make(pok2.Vector, len(y))
+	a := make(pok2.Matrix, len(x))
+	for i := range x {
+		logY[i] = math.Log(y[i])
+		a[i] = pok2.Vector{x[i]}
+	}
+	lin, err := regression.OLSWithIntercept(a, logY)
+	assert.Nil(t, err)
+	p0 := pok2.Vector{math.Exp(lin.Coefficients[0]), lin.Coefficients[1]}
+
+	r, err := regression.CurveFit(exponential, x, y, p0)
+	assert.Nil(t, err)
+	assert.True(t, r.Converged)
+
+	var rss0 float64
+	for i := range x {
+		d := y[i] - exponential(x[i], p0)
+		rss0 += d * d
+	}
+	assert.True(t, r.RSS < rss0)
+
+	// W minimum gradient sumy kwadratów reszt jest zerowy: sum(r[i] * df/dp) = 0
+	for k := range p0 {
+		var g float64
+		for i := range x {
+			d := pok2.Vector{math.Exp(r.Params[1] * x[i]), r.Params[0] * x[i] * math.Exp(r.Params[1]*x[i])}
+			g += r.Residuals[i] * d[k]
+		}
+		assert.InDelta(t, 0, g, 1e-6)
+	}
+}
+
+func TestCurveFitExactlyDetermined(t *testing.T) {
+	r, err := regression.CurveFit(line, pok2.Vector{1, 3}, pok2.Vector{2, 6}, pok2.Vector{0, 0})
+	assert.Nil(t, err)
+
+	assert.True(t, r.Converged)
+	assert.True(t, pok2.Vector{0, 2}.IsSimilar(r.Params, 1e-9))
+	for _, se := range r.StdErrors {
+		assert.True(t, math.IsNaN(se))
+	}
+}
+
+func TestCurveFitSmallScale(t *testing.T) {
+	// J^T J ma tu elementy rzędu 1e-11, więc jej odwracanie eliminacją Gaussa uznaje ją za pojedynczą
+	x := pok2.Vector{1e-6, 2e-6, 3e-6, 4e-6, 5e-6}
+	y := pok2.Vector{2.1e-6, 3.9e-6, 6.2e-6, 7.8e-6, 10.1e-6}
+	proportional := func(x float64, p pok2.Vector) float64 {
+		return p[0] * x
+	}
+
+	r, err := regression.CurveFit(proportional, x, y, pok2.Vector{1})
+	assert.Nil(t, err)
+	assert.True(t, r.Converged)
+
+	// Model liniowy względem parametru, więc wynik pokrywa się z regresją bez wyrazu wolnego
+	a := make(pok2.Matrix, len(x))
+	for i := range x {
+		a[i] = pok2.Vector{x[i]}
+	}
+	ols, err := regression.OLS(a, y)
+	assert.Nil(t, err)
+	assert.InDelta(t, ols.Coefficients[0], r.Params[0], 1e-9)
+	assert.InDelta(t, ols.StdErrors[0], r.StdErrors[0], 1e-9)
+}
+
+func TestCurveFitNonIdentifiable(t *testing.T) {
+	// Dopasowanie wyznacza tylko iloczyn p0*p1, więc J ma rząd 1
+	product := func(x float64, p pok2.Vector) float64 {
+		return p[0] * p[1] * x
+	}
+
+	r, err := regression.CurveFit(product, pok2.Vector{1, 2, 3, 4, 5}, pok2.Vector{2.1, 3.9, 6.2, 7.8, 10.1}, pok2.Vector{1, 1})
+	assert.Nil(t, err)
+	assert.InDelta(t, 110.2/55, r.Params[0]*r.Params[1], 1e-6)
+	for _, se := range r.StdErrors {
+		assert.True(t, math.IsInf(se, 1))
+	}
+}
@@ -0,0 +1,106 @@
+package regression
+
+import (
+	"math"
+)
+
+const (
+	// maxBetaIterations ogranicza liczbę wyrazów ułamka łańcuchowego w betaInc
+	maxBetaIterations = 300
+	// betaTolerance to względna zmiana ułamka łańcuchowego, przy której kończymy iterację
+	betaTolerance = 1e-15
+)
+
+// studentTPValue zwraca dwustronną wartość p statystyki t dla rozkładu t-Studenta z df stopniami swobody
+func studentTPValue(t float64, df int) float64 {
+	if math.IsNaN(t) || df <= 0 {
+		return math.NaN()
+	}
+	if math.IsInf(t, 0) {
+		return 0
+	}
+	v := float64(df)
+	return betaInc(v/2, 0.5, v/(v+t*t))
+}
+
+// fPValue zwraca wartość p statystyki f dla rozkładu F z d1 i d2 stopniami swobody
+func fPValue(f float64, d1, d2 int) float64 {
+	if math.IsNaN(f) || d1 <= 0 || d2 <= 0 {
+		return math.NaN()
+	}
+	if math.IsInf(f, 1) {
+		return 0
+	}
+	v1, v2 := float64(d1), float64(d2)
+	return betaInc(v2/2, v1/2, v2/(v2+v1*f))
+}
+
+// betaInc zwraca regularyzowaną niekompletną funkcję beta I_x(a, b)
+func betaInc(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	// Ułamek łańcuchowy zbiega szybko dla x < (a+1)/(a+b+2), w przeciwnym razie korzystamy z symetrii
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction wyznacza ułamek łańcuchowy niekompletnej funkcji beta zmodyfikowaną metodą Lentza
+func betaContinuedFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxBetaIterations; m++ {
+		fm := float64(m)
+
+		// Wyraz parzysty
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Wyraz nieparzysty
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < betaTolerance {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,241 @@
+package regression
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/53jk1/pok2"
+)
+
+// OLSResult przechowuje wynik regresji liniowej metodą najmniejszych kwadratów wraz z podsumowaniem modelu.
+// Jeśli Intercept jest prawdą, Coefficients[0] jest wyrazem wolnym, a kolejne współczynniki odpowiadają kolumnom X.
+type OLSResult struct {
+	Coefficients pok2.Vector
+	StdErrors    pok2.Vector
+	TStats       pok2.Vector
+	// PValues to dwustronne wartości p testu t dla hipotezy zerowania współczynnika
+	PValues pok2.Vector
+	// Residuals[i] = y[i] - X[i] * Coefficients
+	Residuals pok2.Vector
+
+	// RSquared dla modelu bez wyrazu wolnego liczony jest względem zera, a nie średniej y
+	RSquared    float64
+	AdjRSquared float64
+	// FStatistic testuje hipotezę, że wszystkie współczynniki poza wyrazem wolnym są zerowe
+	FStatistic float64
+	FPValue    float64
+
+	Intercept bool
+	// Observations to liczba obserwacji, a DF liczba stopni swobody reszt
+	Observations int
+	DF           int
+}
+
+// OLS dopasowuje model y = X * b metodą najmniejszych kwadratów, rozwiązując układ rozkładem QR jak LeftDivide.
+// Model nie zawiera wyrazu wolnego, chyba że jest on kolumną X; w przeciwnym razie należy użyć OLSWithIntercept.
+// Zwraca błąd, jeśli X jest pusta lub poszarpana, wymiary X i y nie są zgodne, obserwacji jest mniej niż kolumn
+// lub kolumny X są liniowo zależne (*pok2.RankError). Dla liczby obserwacji równej liczbie kolumn
+// statystyki zależne od wariancji reszt mają wartość NaN.
+func OLS(x pok2.Matrix, y pok2.Vector) (*OLSResult, error) {
+	return fit(x, y, false)
+}
+
+// OLSWithIntercept dopasowuje model y = b0 + X * b, dodając do X kolumnę jedynek na pozycji 0.
+// Zwraca błędy jak OLS.
+func OLSWithIntercept(x pok2.Matrix, y pok2.Vector) (*OLSResult, error) {
+	ones := make(pok2.Vector, len(x))
+	for i := range ones {
+		ones[i] = 1
+	}
+
+	a, err := x.InsertCol(0, ones)
+	if err != nil {
+		return nil, err
+	}
+	return fit(a, y, true)
+}
+
+func fit(a pok2.Matrix, y pok2.Vector, intercept bool) (*OLSResult, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	n, p := a.Dim()
+	if n != len(y) {
+		return nil, fmt.Errorf("Liczba wierszy X (%d) nie pasuje do długości y (%d)", n, len(y))
+	}
+	if n < p {
+		return nil, fmt.Errorf("Liczba obserwacji (%d) jest mniejsza niż liczba współczynników (%d)", n, p)
+	}
+
+	b := make(pok2.Matrix, n)
+	for i := range y {
+		b[i] = pok2.Vector{y[i]}
+	}
+
+	qr, err := a.QR()
+	if err != nil {
+		return nil, err
+	}
+	c, err := qr.Solve(b)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &OLSResult{
+		Coefficients: make(pok2.Vector, p),
+		Residuals:    make(pok2.Vector, n),
+		Intercept:    intercept,
+		Observations: n,
+		DF:           n - p,
+	}
+	for k := range c {
+		r.Coefficients[k] = c[k][0]
+	}
+
+	var rss, tss, mean float64
+	if intercept {
+		mean = y.Sum() / float64(n)
+	}
+	for i := range y {
+		est, err := a[i].Dot(r.Coefficients)
+		if err != nil {
+			return nil, err
+		}
+		r.Residuals[i] = y[i] - est
+		rss += r.Residuals[i] * r.Residuals[i]
+		tss += (y[i] - mean) * (y[i] - mean)
+	}
+
+	// Dla stałych wartości y, które model odtwarza dokładnie, przyjmujemy R^2 = 1
+	r.RSquared = 1
+	if tss != 0 {
+		r.RSquared = 1 - rss/tss
+	}
+
+	r.StdErrors = stdErrors(qr.R, rss, r.DF)
+
+	r.TStats = make(pok2.Vector, p)
+	r.PValues = make(pok2.Vector, p)
+	for k := range r.TStats {
+		r.TStats[k] = r.Coefficients[k] / r.StdErrors[k]
+		r.PValues[k] = studentTPValue(r.TStats[k], r.DF)
+	}
+
+	// Liczba regresorów testowanych statystyką F nie obejmuje wyrazu wolnego
+	regressors := p
+	centered := 0
+	if intercept {
+		regressors--
+		centered = 1
+	}
+
+	r.AdjRSquared, r.FStatistic, r.FPValue = math.NaN(), math.NaN(), math.NaN()
+	if r.DF > 0 {
+		r.AdjRSquared = 1 - (1-r.RSquared)*float64(n-centered)/float64(r.DF)
+		if regressors > 0 {
+			r.FStatistic = ((tss - rss) / float64(regressors)) / (rss / float64(r.DF))
+			r.FPValue = fPValue(r.FStatistic, regressors, r.DF)
+		}
+	}
+
+	return r, nil
+}
+
+// stdErrors zwraca błędy standardowe współczynników sqrt(s^2 * diag((A^T A)^-1)), gdzie s^2 = RSS/df.
+// (A^T A)^-1 wyznaczana jest z czynnika R rozkładu QR macierzy A, bez tworzenia A^T A.
+func stdErrors(r pok2.Matrix, rss float64, df int) pok2.Vector {
+	se := make(pok2.Vector, len(r))
+
+	if df == 0 {
+		for k := range se {
+			se[k] = math.NaN()
+		}
+		return se
+	}
+	s2 := rss / float64(df)
+
+	cov := unscaledCovariance(r)
+	for k := range se {
+		se[k] = math.Sqrt(s2 * cov[k][k])
+	}
+	return se
+}
+
+// unscaledCovariance zwraca (A^T A)^-1 = R^-1 * R^-T dla kwadratowego, górnotrójkątnego czynnika R
+// rozkładu A = Q*R. Zerowy element na przekątnej R daje nieskończone lub nieokreślone (NaN) wartości.
+func unscaledCovariance(r pok2.Matrix) pok2.Matrix {
+	p := len(r)
+
+	// R^-1 jest górnotrójkątna; kolumnę k wyznaczamy podstawieniem wstecz z R * x = e_k
+	inv := make(pok2.Matrix, p)
+	for i := range inv {
+		inv[i] = make(pok2.Vector, p)
+	}
+	for k := 0; k < p; k++ {
+		for i := k; i >= 0; i-- {
+			var s float64
+			if i == k {
+				s = 1
+			}
+			for j := i + 1; j <= k; j++ {
+				s -= r[i][j] * inv[j][k]
+			}
+			inv[i][k] = s / r[i][i]
+		}
+	}
+
+	cov := make(pok2.Matrix, p)
+	for i := range cov {
+		cov[i] = make(pok2.Vector, p)
+		for j := range cov[i] {
+			l := i
+			if j > l {
+				l = j
+			}
+			for ; l < p; l++ {
+				cov[i][j] += inv[i][l] * inv[j][l]
+			}
+		}
+	}
+	return cov
+}
+
+// Predict zwraca wartość modelu dla wektora zmiennych x (bez kolumny wyrazu wolnego) oraz błąd (jeśli istnieje).
+func (r *OLSResult) Predict(x pok2.Vector) (float64, error) {
+	c := r.Coefficients
+	var est float64
+	if r.Intercept {
+		est = c[0]
+		c = c[1:]
+	}
+
+	dot, err := x.Dot(c)
+	if err != nil {
+		return 0, fmt.Errorf("Liczba zmiennych (%d) nie pasuje do liczby współczynników (%d)", len(x), len(c))
+	}
+	return est + dot, nil
+}
+
+// Summary zwraca tabelę współczynników ze statystykami oraz miary dopasowania modelu.
+// Zmienne nazywane są x1, x2, ..., a wyraz wolny const.
+func (r *OLSResult) Summary() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%-8s %14s %14s %10s %10s\n", "", "Współczynnik", "Błąd std.", "t", "P>|t|")
+	for k := range r.Coefficients {
+		name := fmt.Sprintf("x%d", k+1)
+		if r.Intercept {
+			name = fmt.Sprintf("x%d", k)
+			if k == 0 {
+				name = "const"
+			}
+		}
+		fmt.Fprintf(&sb, "%-8s %14.6g %14.6g %10.4f %10.4f\n", name, r.Coefficients[k], r.StdErrors[k], r.TStats[k], r.PValues[k])
+	}
+
+	fmt.Fprintf(&sb, "\nR²: %.6f   Skorygowany R²: %.6f\n", r.RSquared, r.AdjRSquared)
+	fmt.Fprintf(&sb, "F: %.6g (p = %.4g)   Obserwacje: %d   Stopnie swobody reszt: %d\n", r.FStatistic, r.FPValue, r.Observations, r.DF)
+	return sb.String()
+}
@@ -0,0 +1,210 @@
+package regression_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/regression"
+	"github.com/stretchr/testify/assert"
+)
+
+// Dwustronne wartości p rozkładu t-Studenta w postaci jawnej dla 1, 2 i 3 stopni swobody
+func tPValue1(t float64) float64 {
+	return 1 - 2/math.Pi*math.Atan(math.Abs(t))
+}
+
+func tPValue2(t float64) float64 {
+	return 1 - math.Abs(t)/math.Sqrt(2+t*t)
+}
+
+func tPValue3(t float64) float64 {
+	u := math.Abs(t) / math.Sqrt(3)
+	return 1 - 2/math.Pi*(u/(1+u*u)+math.Atan(u))
+}
+
+func TestOLS(t *testing.T) {
+	slope, noIntercept := 31.0/14, math.Sqrt(5.0/28/14)
+
+	cases := map[string]struct {
+		x         pok2.Matrix
+		y         pok2.Vector
+		intercept bool
+		expected  regression.OLSResult
+	}{
+		"simple linear regression": {
+			x:         pok2.Matrix{{1}, {2}, {3}, {4}, {5}},
+			y:         pok2.Vector{2, 4, 5, 4, 5},
+			intercept: true,
+			expected: regression.OLSResult{
+				Coefficients: pok2.Vector{2.2, 0.6},
+				StdErrors:    pok2.Vector{math.Sqrt(0.88), math.Sqrt(0.08)},
+				TStats:       pok2.Vector{2.2 / math.Sqrt(0.88), 0.6 / math.Sqrt(0.08)},
+				PValues:      pok2.Vector{tPValue3(2.2 / math.Sqrt(0.88)), tPValue3(0.6 / math.Sqrt(0.08))},
+				Residuals:    pok2.Vector{-0.8, 0.6, 1, -0.6, -0.2},
+				RSquared:     0.6,
+				AdjRSquared:  1 - 0.4*4/3,
+				FStatistic:   4.5,
+				FPValue:      tPValue3(0.6 / math.Sqrt(0.08)),
+				Intercept:    true,
+				Observations: 5,
+				DF:           3,
+			},
+		},
+		"two regressors": {
+			// y = 1 + 2*x1 - x2 + e, gdzie e jest ortogonalne do kolumn modelu
+			x:         pok2.Matrix{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+			y:         pok2.Vector{1.5, 2.5, -0.5, 2.5},
+			intercept: true,
+			expected: regression.OLSResult{
+				Coefficients: pok2.Vector{1, 2, -1},
+				StdErrors:    pok2.Vector{math.Sqrt(0.75), 1, 1},
+				TStats:       pok2.Vector{1 / math.Sqrt(0.75), 2, -1},
+				PValues:      pok2.Vector{tPValue1(1 / math.Sqrt(0.75)), tPValue1(2), tPValue1(-1)},
+				Residuals:    pok2.Vector{0.5, -0.5, -0.5, 0.5},
+				RSquared:     5.0 / 6,
+				AdjRSquared:  0.5,
+				FStatistic:   2.5,
+				FPValue:      math.Sqrt(1.0 / 6),
+				Intercept:    true,
+				Observations: 4,
+				DF:           1,
+			},
+		},
+		"without intercept": {
+			x:         pok2.Matrix{{1}, {2}, {3}},
+			y:         pok2.Vector{2, 4, 7},
+			intercept: false,
+			expected: regression.OLSResult{
+				Coefficients: pok2.Vector{slope},
+				StdErrors:    pok2.Vector{noIntercept},
+				TStats:       pok2.Vector{slope / noIntercept},
+				PValues:      pok2.Vector{tPValue2(slope / noIntercept)},
+				Residuals:    pok2.Vector{2 - slope, 4 - 2*slope, 7 - 3*slope},
+				RSquared:     1 - 5.0/14/69,
+				AdjRSquared:  1 - 5.0/14/69*3/2,
+				FStatistic:   (slope / noIntercept) * (slope / noIntercept),
+				FPValue:      tPValue2(slope / noIntercept),
+				Intercept:    false,
+				Observations: 3,
+				DF:           2,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fit := regression.OLS
+			if c.intercept {
+				fit = regression.OLSWithIntercept
+			}
+
+			r, err := fit(c.x, c.y)
+			assert.Nil(t, err)
+
+			assert.True(t, c.expected.Coefficients.IsSimilar(r.Coefficients, 1e-10))
+			assert.True(t, c.expected.StdErrors.IsSimilar(r.StdErrors, 1e-10))
+			assert.True(t, c.expected.TStats.IsSimilar(r.TStats, 1e-9))
+			assert.True(t, c.expected.PValues.IsSimilar(r.PValues, 1e-10))
+			assert.True(t, c.expected.Residuals.IsSimilar(r.Residuals, 1e-10))
+			assert.InDelta(t, c.expected.RSquared, r.RSquared, 1e-10)
+			assert.InDelta(t, c.expected.AdjRSquared, r.AdjRSquared, 1e-10)
+			assert.InDelta(t, c.expected.FStatistic, r.FStatistic, 1e-9)
+			assert.InDelta(t, c.expected.FPValue, r.FPValue, 1e-10)
+			assert.Equal(t, c.expected.Intercept, r.Intercept)
+			assert.Equal(t, c.expected.Observations, r.Observations)
+			assert.Equal(t, c.expected.DF, r.DF)
+		})
+	}
+}
+
+func TestOLSErrors(t *testing.T) {
+	cases := map[string]struct {
+		x             pok2.Matrix
+		y             pok2.Vector
+		expectedError error
+	}{
+		"empty x": {
+			x:             pok2.Matrix{},
+			y:             pok2.Vector{},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"ragged x": {
+			x:             pok2.Matrix{{1, 2}, {3}},
+			y:             pok2.Vector{1, 2},
+			expectedError: &pok2.RaggedError{Row: 1, Len: 1, Cols: 2},
+		},
+		"wrong y size": {
+			x:             pok2.Matrix{{1}, {2}, {3}},
+			y:             pok2.Vector{1, 2},
+			expectedError: fmt.Errorf("Liczba wierszy X (3) nie pasuje do długości y (2)"),
+		},
+		"fewer observations than coefficients": {
+			x:             pok2.Matrix{{1, 2}},
+			y:             pok2.Vector{1},
+			expectedError: fmt.Errorf("Liczba obserwacji (1) jest mniejsza niż liczba współczynników (3)"),
+		},
+		"collinear columns": {
+			x:             pok2.Matrix{{1, 2}, {2, 4}, {3, 6}, {4, 8}},
+			y:             pok2.Vector{1, 2, 3, 5},
+			expectedError: &pok2.RankError{Rank: 2, Cols: 3},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := regression.OLSWithIntercept(c.x, c.y)
+			assert.Equal(t, c.expectedError, err)
+			assert.Nil(t, r)
+		})
+	}
+}
+
+func TestOLSExactFit(t *testing.T) {
+	r, err := regression.OLSWithIntercept(pok2.Matrix{{1}, {3}}, pok2.Vector{2, 6})
+	assert.Nil(t, err)
+
+	assert.True(t, pok2.Vector{0, 2}.IsSimilar(r.Coefficients, 1e-12))
+	assert.Equal(t, 0, r.DF)
+	assert.True(t, math.IsNaN(r.StdErrors[1]))
+	assert.True(t, math.IsNaN(r.PValues[1]))
+	assert.True(t, math.IsNaN(r.AdjRSquared))
+	assert.True(t, math.IsNaN(r.FStatistic))
+}
+
+func TestOLSSmallScale(t *testing.T) {
+	// Przypadek "two regressors" z kolumnami X przeskalowanymi o 1e-6 i 1e-7; A^T A jest tu numerycznie pojedyncza
+	x := pok2.Matrix{{0, 0}, {1e-6, 0}, {0, 1e-7}, {1e-6, 1e-7}}
+	r, err := regression.OLSWithIntercept(x, pok2.Vector{1.5, 2.5, -0.5, 2.5})
+	assert.Nil(t, err)
+
+	expected, err := regression.OLSWithIntercept(pok2.Matrix{{0, 0}, {1, 0}, {0, 1}, {1, 1}}, pok2.Vector{1.5, 2.5, -0.5, 2.5})
+	assert.Nil(t, err)
+
+	scale := pok2.Vector{1, 1e6, 1e7}
+	for k := range scale {
+		assert.InDelta(t, expected.Coefficients[k]*scale[k], r.Coefficients[k], 1e-8*scale[k])
+		assert.InDelta(t, expected.StdErrors[k]*scale[k], r.StdErrors[k], 1e-8*scale[k])
+		assert.InDelta(t, expected.TStats[k], r.TStats[k], 1e-8)
+	}
+	assert.InDelta(t, expected.RSquared, r.RSquared, 1e-10)
+}
+
+func TestOLSResultPredictAndSummary(t *testing.T) {
+	r, err := regression.OLSWithIntercept(pok2.Matrix{{0, 0}, {1, 0}, {0, 1}, {1, 1}}, pok2.Vector{1.5, 2.5, -0.5, 2.5})
+	assert.Nil(t, err)
+
+	est, err := r.Predict(pok2.Vector{2, 3})
+	assert.Nil(t, err)
+	assert.InDelta(t, 1+2*2-3, est, 1e-12)
+
+	_, err = r.Predict(pok2.Vector{2})
+	assert.Equal(t, fmt.Errorf("Liczba zmiennych (1) nie pasuje do liczby współczynników (2)"), err)
+
+	summary := r.Summary()
+	for _, s := range []string{"const", "x1", "x2", "R²", "Skorygowany R²", "Obserwacje: 4", "Stopnie swobody reszt: 1"} {
+		assert.True(t, strings.Contains(summary, s))
+	}
+}
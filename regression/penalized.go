@@ -0,0 +1,433 @@
+package regression
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/53jk1/pok2"
+)
+
+const (
+	// maxCoordinateDescentIterations ogranicza liczbę pełnych przebiegów po współrzędnych w ElasticNet
+	maxCoordinateDescentIterations = 10000
+	// coordinateDescentTolerance ogranicza największą zmianę dopasowania z*delta^2 w przebiegu po współrzędnych
+	// względem średniego kwadratu wycentrowanego y, przy której uznajemy zbieżność; odpowiada to względnej
+	// zmianie współczynników rzędu 1e-10
+	coordinateDescentTolerance = 1e-20
+	// activeSetSlack to względny zapas, z jakim sprawdzamy warunki optymalności po rozwiązaniu układu
+	// dla aktywnych współrzędnych
+	activeSetSlack = 1e-9
+	// pathLength to liczba wartości lambda w domyślnej ścieżce regularyzacji
+	pathLength = 100
+	// pathMinRatio to stosunek najmniejszej do największej lambdy w domyślnej ścieżce regularyzacji
+	pathMinRatio = 1e-3
+	// pathMinAlpha zastępuje alpha = 0 przy wyznaczaniu największej lambdy domyślnej ścieżki
+	pathMinAlpha = 1e-3
+)
+
+// Penalized przechowuje wynik regresji liniowej z karą elastic net, minimalizującej
+// 1/(2n) * |y - b0 - X*b|^2 + Lambda * ((1-Alpha)/2 * |b|^2 + Alpha * |b|_1).
+// Wyraz wolny Intercept nie podlega karze.
+type Penalized struct {
+	Intercept    float64
+	Coefficients pok2.Vector
+
+	Lambda float64
+	// Alpha = 0 oznacza regresję grzbietową, a Alpha = 1 regresję Lasso
+	Alpha float64
+	// Iterations to liczba przebiegów metody spadku współrzędnych, a Converged informuje, czy spełniono
+	// kryterium zbieżności przed osiągnięciem limitu przebiegów; dla Ridge Iterations = 0 i Converged = true
+	Iterations int
+	Converged  bool
+}
+
+// Ridge dopasowuje regresję grzbietową, rozwiązując rozkładem Cholesky'ego
+// rozszerzony układ równań normalnych (Xc^T * Xc + n*lambda*I) * b = Xc^T * yc, gdzie Xc i yc są wycentrowane.
+// Dla lambda > 0 macierz układu jest dodatnio określona także wtedy, gdy kolumn jest więcej niż obserwacji.
+// Zwraca błąd, jeśli X jest pusta lub poszarpana, wymiary X i y nie są zgodne lub lambda jest ujemna.
+func Ridge(x pok2.Matrix, y pok2.Vector, lambda float64) (*Penalized, error) {
+	if err := validatePenalty(lambda, 0); err != nil {
+		return nil, err
+	}
+
+	d, err := center(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	xt, err := d.x.Transpose()
+	if err != nil {
+		return nil, err
+	}
+	a, err := xt.MultiplyBy(d.x)
+	if err != nil {
+		return nil, err
+	}
+
+	n := float64(len(y))
+	for j := range a {
+		a[j][j] += n * lambda
+	}
+
+	b := make(pok2.Matrix, len(d.y))
+	for i := range d.y {
+		b[i] = pok2.Vector{d.y[i]}
+	}
+	rhs, err := xt.MultiplyBy(b)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := a.Cholesky()
+	if err != nil {
+		return nil, err
+	}
+	c, err := ch.Solve(rhs)
+	if err != nil {
+		return nil, err
+	}
+
+	coef := make(pok2.Vector, len(c))
+	for j := range c {
+		coef[j] = c[j][0]
+	}
+	return d.result(coef, lambda, 0, 0, true), nil
+}
+
+// Lasso dopasowuje regresję z karą L1 metodą spadku współrzędnych; jest równoważne ElasticNet(x, y, lambda, 1).
+func Lasso(x pok2.Matrix, y pok2.Vector, lambda float64) (*Penalized, error) {
+	return ElasticNet(x, y, lambda, 1)
+}
+
+// ElasticNet dopasowuje regresję z karą elastic net metodą cyklicznego spadku współrzędnych.
+// Zwraca błąd, jeśli X jest pusta lub poszarpana, wymiary X i y nie są zgodne, lambda jest ujemna
+// lub alpha nie należy do przedziału [0, 1]. Brak zbieżności nie jest błędem, lecz jest sygnalizowany przez Converged.
+func ElasticNet(x pok2.Matrix, y pok2.Vector, lambda, alpha float64) (*Penalized, error) {
+	path, err := ElasticNetPath(x, y, alpha, pok2.Vector{lambda})
+	if err != nil {
+		return nil, err
+	}
+	return path[0], nil
+}
+
+// LassoPath zwraca ścieżkę regularyzacji Lasso; jest równoważne ElasticNetPath(x, y, 1, lambdas).
+func LassoPath(x pok2.Matrix, y pok2.Vector, lambdas pok2.Vector) ([]*Penalized, error) {
+	return ElasticNetPath(x, y, 1, lambdas)
+}
+
+// ElasticNetPath dopasowuje modele elastic net dla kolejnych wartości lambdas,
+// rozpoczynając każde dopasowanie od współczynników poprzedniego (najlepiej więc podawać lambdy malejąco).
+// Pusta lambdas oznacza domyślną ścieżkę 100 wartości rozłożonych logarytmicznie od najmniejszej lambdy,
+// dla której wszystkie współczynniki są zerowe, do jej tysięcznej części.
+// Zwraca błędy jak ElasticNet; brak zbieżności dla jednej lambdy nie przerywa ścieżki.
+func ElasticNetPath(x pok2.Matrix, y pok2.Vector, alpha float64, lambdas pok2.Vector) ([]*Penalized, error) {
+	if err := validatePenalty(0, alpha); err != nil {
+		return nil, err
+	}
+	for _, lambda := range lambdas {
+		if err := validatePenalty(lambda, alpha); err != nil {
+			return nil, err
+		}
+	}
+
+	d, err := center(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lambdas) == 0 {
+		lambdas = d.lambdaPath(alpha)
+	}
+
+	_, p := d.x.Dim()
+	coef := make(pok2.Vector, p)
+	path := make([]*Penalized, len(lambdas))
+	for k, lambda := range lambdas {
+		its, converged := d.coordinateDescent(coef, lambda, alpha)
+		path[k] = d.result(append(pok2.Vector{}, coef...), lambda, alpha, its, converged)
+	}
+	return path, nil
+}
+
+// Predict zwraca wartość modelu dla wektora zmiennych x oraz błąd (jeśli istnieje).
+func (m *Penalized) Predict(x pok2.Vector) (float64, error) {
+	dot, err := x.Dot(m.Coefficients)
+	if err != nil {
+		return 0, fmt.Errorf("Liczba zmiennych (%d) nie pasuje do liczby współczynników (%d)", len(x), len(m.Coefficients))
+	}
+	return m.Intercept + dot, nil
+}
+
+func validatePenalty(lambda, alpha float64) error {
+	if lambda < 0 || math.IsNaN(lambda) {
+		return fmt.Errorf("Parametr regularyzacji lambda nie może być ujemny")
+	}
+	if alpha < 0 || alpha > 1 || math.IsNaN(alpha) {
+		return fmt.Errorf("Parametr alpha musi należeć do przedziału [0, 1]")
+	}
+	return nil
+}
+
+// centered przechowuje wycentrowane dane, dzięki którym wyraz wolny wyznaczany jest poza karą
+type centered struct {
+	x     pok2.Matrix
+	y     pok2.Vector
+	xMean pok2.Vector
+	yMean float64
+}
+
+func center(x pok2.Matrix, y pok2.Vector) (*centered, error) {
+	if err := x.Validate(); err != nil {
+		return nil, err
+	}
+
+	n, p := x.Dim()
+	if n != len(y) {
+		return nil, fmt.Errorf("Liczba wierszy X (%d) nie pasuje do długości y (%d)", n, len(y))
+	}
+
+	d := &centered{
+		x:     make(pok2.Matrix, n),
+		y:     make(pok2.Vector, n),
+		xMean: make(pok2.Vector, p),
+		yMean: y.Sum() / float64(n),
+	}
+	for i := range x {
+		for j := range x[i] {
+			d.xMean[j] += x[i][j] / float64(n)
+		}
+	}
+	for i := range x {
+		d.x[i] = make(pok2.Vector, p)
+		for j := range x[i] {
+			d.x[i][j] = x[i][j] - d.xMean[j]
+		}
+		d.y[i] = y[i] - d.yMean
+	}
+	return d, nil
+}
+
+func (d *centered) result(coef pok2.Vector, lambda, alpha float64, its int, converged bool) *Penalized {
+	// Dot nie zwraca błędu, bo xMean i coef mają długość równą liczbie kolumn
+	shift, _ := d.xMean.Dot(coef)
+	return &Penalized{
+		Intercept:    d.yMean - shift,
+		Coefficients: coef,
+		Lambda:       lambda,
+		Alpha:        alpha,
+		Iterations:   its,
+		Converged:    converged,
+	}
+}
+
+// lambdaPath zwraca domyślną ścieżkę lambd; największa z nich zeruje wszystkie współczynniki
+func (d *centered) lambdaPath(alpha float64) pok2.Vector {
+	n, p := d.x.Dim()
+
+	var top float64
+	for j := 0; j < p; j++ {
+		var g float64
+		for i := 0; i < n; i++ {
+			g += d.x[i][j] * d.y[i]
+		}
+		top = math.Max(top, math.Abs(g))
+	}
+	top /= float64(n) * math.Max(alpha, pathMinAlpha)
+
+	lambdas := make(pok2.Vector, pathLength)
+	for k := range lambdas {
+		lambdas[k] = top * math.Pow(pathMinRatio, float64(k)/float64(pathLength-1))
+	}
+	return lambdas
+}
+
+// coordinateDescent poprawia coef w miejscu, aktualizując kolejno każdą współrzędną
+// operatorem miękkiego progowania. Gdy zbiór niezerowych współczynników i ich znaki nie zmieniają się
+// w przebiegu, rozwiązuje bezpośrednio układ dla aktywnych współrzędnych, bo przy silnie skorelowanych
+// kolumnach spadek współrzędnych zbiega bardzo wolno. Zwraca liczbę wykonanych przebiegów oraz informację,
+// czy osiągnięto zbieżność.
+func (d *centered) coordinateDescent(coef pok2.Vector, lambda, alpha float64) (int, bool) {
+	n, p := d.x.Dim()
+	nf := float64(n)
+
+	// Średnie kwadraty kolumn i reszty dla bieżących współczynników
+	z := make(pok2.Vector, p)
+	r := append(pok2.Vector{}, d.y...)
+	var null float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			z[j] += d.x[i][j] * d.x[i][j] / nf
+			r[i] -= d.x[i][j] * coef[j]
+		}
+		null += d.y[i] * d.y[i] / nf
+	}
+
+	l1, l2 := lambda*alpha, lambda*(1-alpha)
+
+	// tried to wzorzec znaków, dla którego rozwiązanie układu aktywnych współrzędnych już zawiodło
+	var tried pok2.Vector
+	for its := 1; its <= maxCoordinateDescentIterations; its++ {
+		before := signs(coef)
+		var change float64
+		for j := 0; j < p; j++ {
+			// Kolumna stała po wycentrowaniu nie wpływa na dopasowanie
+			if z[j] == 0 {
+				coef[j] = 0
+				continue
+			}
+
+			rho := z[j] * coef[j]
+			for i := 0; i < n; i++ {
+				rho += d.x[i][j] * r[i] / nf
+			}
+
+			c := softThreshold(rho, l1) / (z[j] + l2)
+			if delta := c - coef[j]; delta != 0 {
+				for i := 0; i < n; i++ {
+					r[i] -= d.x[i][j] * delta
+				}
+				coef[j] = c
+				change = math.Max(change, z[j]*delta*delta)
+			}
+		}
+
+		if change <= coordinateDescentTolerance*null {
+			return its, true
+		}
+
+		if after := signs(coef); after.IsSimilar(before, 0) && !after.IsSimilar(tried, 0) {
+			if d.solveActive(coef, after, z, null, l1, l2) {
+				return its, true
+			}
+
+			// Ten sam wzorzec znaków próbujemy ponownie tylko wtedy, gdy solveActive wyzerowało któryś współczynnik
+			tried = nil
+			if signs(coef).IsSimilar(after, 0) {
+				tried = after
+			}
+
+			// solveActive mogło przesunąć coef, więc wyznaczamy reszty od nowa
+			copy(r, d.y)
+			for i := 0; i < n; i++ {
+				for j := 0; j < p; j++ {
+					r[i] -= d.x[i][j] * coef[j]
+				}
+			}
+		}
+	}
+	return maxCoordinateDescentIterations, false
+}
+
+// solveActive rozwiązuje warunki optymalności (Xa^T Xa / n + l2*I) * b = Xa^T y / n - l1*s dla współrzędnych
+// o niezerowym znaku s. Jeśli rozwiązanie zachowuje znaki s, a pozostałe współrzędne spełniają |Xj^T r / n| <= l1,
+// zapisuje je w coef i zwraca prawdę. Jeśli rozwiązanie zmienia któryś znak, przesuwa coef w jego stronę do
+// pierwszego wyzerowanego współczynnika; przy stałych znakach funkcja celu jest kwadratowa, więc ten krok jej nie zwiększa.
+func (d *centered) solveActive(coef, s, z pok2.Vector, null, l1, l2 float64) bool {
+	n, p := d.x.Dim()
+	nf := float64(n)
+
+	var active []int
+	for j := 0; j < p; j++ {
+		if s[j] != 0 {
+			active = append(active, j)
+		}
+	}
+	if len(active) == 0 {
+		return false
+	}
+
+	a := make(pok2.Matrix, len(active))
+	b := make(pok2.Matrix, len(active))
+	for u, j := range active {
+		a[u] = make(pok2.Vector, len(active))
+		for v, k := range active {
+			for i := 0; i < n; i++ {
+				a[u][v] += d.x[i][j] * d.x[i][k] / nf
+			}
+		}
+		a[u][u] += l2
+
+		var g float64
+		for i := 0; i < n; i++ {
+			g += d.x[i][j] * d.y[i] / nf
+		}
+		b[u] = pok2.Vector{g - l1*s[j]}
+	}
+
+	ch, err := a.Cholesky()
+	if err != nil {
+		return false
+	}
+	sol, err := ch.Solve(b)
+	if err != nil {
+		return false
+	}
+
+	// step to największy krok w stronę rozwiązania, przy którym współczynniki nie zmieniają znaku
+	step, blocking := 1.0, -1
+	for u, j := range active {
+		if sol[u][0]*s[j] <= 0 {
+			if t := coef[j] / (coef[j] - sol[u][0]); t < step {
+				step, blocking = t, j
+			}
+		}
+	}
+	if blocking >= 0 {
+		for u, j := range active {
+			coef[j] += step * (sol[u][0] - coef[j])
+		}
+		coef[blocking] = 0
+		return false
+	}
+
+	next := make(pok2.Vector, p)
+	for u, j := range active {
+		next[j] = sol[u][0]
+	}
+
+	r := append(pok2.Vector{}, d.y...)
+	for i := 0; i < n; i++ {
+		for _, j := range active {
+			r[i] -= d.x[i][j] * next[j]
+		}
+	}
+	for j := 0; j < p; j++ {
+		if s[j] != 0 || z[j] == 0 {
+			continue
+		}
+		var g float64
+		for i := 0; i < n; i++ {
+			g += d.x[i][j] * r[i] / nf
+		}
+		if math.Abs(g) > l1+activeSetSlack*math.Sqrt(z[j]*null) {
+			return false
+		}
+	}
+
+	copy(coef, next)
+	return true
+}
+
+// signs zwraca wektor znaków (-1, 0 lub 1) kolejnych współczynników
+func signs(coef pok2.Vector) pok2.Vector {
+	s := make(pok2.Vector, len(coef))
+	for j, c := range coef {
+		switch {
+		case c > 0:
+			s[j] = 1
+		case c < 0:
+			s[j] = -1
+		}
+	}
+	return s
+}
+
+func softThreshold(v, t float64) float64 {
+	switch {
+	case v > t:
+		return v - t
+	case v < -t:
+		return v + t
+	}
+	return 0
+}
@@ -0,0 +1,273 @@
+package regression_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/regression"
+	"github.com/stretchr/testify/assert"
+)
+
+// wideX ma więcej kolumn niż wierszy, więc X^T X jest pojedyncza
+var (
+	wideX = pok2.Matrix{
+		{1, 2, 0, 1, 3},
+		{0, 1, 1, 2, 1},
+		{2, 0, 1, 1, 0},
+		{1, 1, 2, 0, 2},
+	}
+	wideY = pok2.Vector{3, 1, 2, 4}
+)
+
+// assertOptimal sprawdza warunki optymalności Karusha-Kuhna-Tuckera dla kary elastic net
+func assertOptimal(t *testing.T, x pok2.Matrix, y pok2.Vector, m *regression.Penalized) {
+	n := float64(len(y))
+
+	r := make(pok2.Vector, len(y))
+	for i := range y {
+		est, err := m.Predict(x[i])
+		assert.Nil(t, err)
+		r[i] = y[i] - est
+	}
+	assert.InDelta(t, 0, r.Sum(), 1e-8)
+
+	for j := range m.Coefficients {
+		var g float64
+		for i := range x {
+			g += x[i][j] * r[i] / n
+		}
+		g -= m.Lambda * (1 - m.Alpha) * m.Coefficients[j]
+
+		b := m.Coefficients[j]
+		switch {
+		case b > 0:
+			assert.InDelta(t, m.Lambda*m.Alpha, g, 1e-8)
+		case b < 0:
+			assert.InDelta(t, -m.Lambda*m.Alpha, g, 1e-8)
+		default:
+			assert.True(t, math.Abs(g) <= m.Lambda*m.Alpha+1e-8)
+		}
+	}
+}
+
+func TestRidge(t *testing.T) {
+	cases := map[string]struct {
+		x                    pok2.Matrix
+		y                    pok2.Vector
+		lambda               float64
+		expectedIntercept    float64
+		expectedCoefficients pok2.Vector
+		expectedError        error
+	}{
+		"simple regression": {
+			x:                    pok2.Matrix{{1}, {2}, {3}, {4}, {5}},
+			y:                    pok2.Vector{2, 4, 5, 4, 5},
+			lambda:               0.4,
+			expectedIntercept:    2.5,
+			expectedCoefficients: pok2.Vector{0.5},
+		},
+		"no penalty equals least squares": {
+			x:                    pok2.Matrix{{1}, {2}, {3}, {4}, {5}},
+			y:                    pok2.Vector{2, 4, 5, 4, 5},
+			lambda:               0,
+			expectedIntercept:    2.2,
+			expectedCoefficients: pok2.Vector{0.6},
+		},
+		"singular without penalty": {
+			x:             wideX,
+			y:             wideY,
+			lambda:        0,
+			expectedError: fmt.Errorf("Macierz nie jest dodatnio określona"),
+		},
+		"negative lambda": {
+			x:             wideX,
+			y:             wideY,
+			lambda:        -1,
+			expectedError: fmt.Errorf("Parametr regularyzacji lambda nie może być ujemny"),
+		},
+		"wrong y size": {
+			x:             wideX,
+			y:             pok2.Vector{1, 2},
+			lambda:        1,
+			expectedError: fmt.Errorf("Liczba wierszy X (4) nie pasuje do długości y (2)"),
+		},
+		"ragged x": {
+			x:             pok2.Matrix{{1, 2}, {3}},
+			y:             pok2.Vector{1, 2},
+			lambda:        1,
+			expectedError: &pok2.RaggedError{Row: 1, Len: 1, Cols: 2},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := regression.Ridge(c.x, c.y, c.lambda)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				assert.Nil(t, m)
+				return
+			}
+
+			assert.InDelta(t, c.expectedIntercept, m.Intercept, 1e-12)
+			assert.True(t, c.expectedCoefficients.IsSimilar(m.Coefficients, 1e-12))
+			assertOptimal(t, c.x, c.y, m)
+		})
+	}
+}
+
+func TestRidgeWideMatrix(t *testing.T) {
+	_, err := regression.OLSWithIntercept(wideX, wideY)
+	assert.NotNil(t, err)
+
+	m, err := regression.Ridge(wideX, wideY, 0.1)
+	assert.Nil(t, err)
+	assert.Len(t, m.Coefficients, 5)
+	assertOptimal(t, wideX, wideY, m)
+
+	// Elastic net z alpha = 0 minimalizuje tę samą funkcję celu
+	e, err := regression.ElasticNet(wideX, wideY, 0.1, 0)
+	assert.Nil(t, err)
+	assert.True(t, e.Converged)
+	assert.InDelta(t, m.Intercept, e.Intercept, 1e-9)
+	assert.True(t, m.Coefficients.IsSimilar(e.Coefficients, 1e-9))
+}
+
+func TestElasticNet(t *testing.T) {
+	cases := map[string]struct {
+		x                    pok2.Matrix
+		y                    pok2.Vector
+		lambda               float64
+		alpha                float64
+		expectedIntercept    float64
+		expectedCoefficients pok2.Vector
+		expectedError        error
+	}{
+		"lasso shrinks slope": {
+			x:                    pok2.Matrix{{1}, {2}, {3}, {4}, {5}},
+			y:                    pok2.Vector{2, 4, 5, 4, 5},
+			lambda:               0.2,
+			alpha:                1,
+			expectedIntercept:    2.5,
+			expectedCoefficients: pok2.Vector{0.5},
+		},
+		"lasso zeroes slope": {
+			x:                    pok2.Matrix{{1}, {2}, {3}, {4}, {5}},
+			y:                    pok2.Vector{2, 4, 5, 4, 5},
+			lambda:               2,
+			alpha:                1,
+			expectedIntercept:    4,
+			expectedCoefficients: pok2.Vector{0},
+		},
+		"elastic net": {
+			x:                    pok2.Matrix{{1}, {2}, {3}, {4}, {5}},
+			y:                    pok2.Vector{2, 4, 5, 4, 5},
+			lambda:               0.4,
+			alpha:                0.5,
+			expectedIntercept:    4 - 3/2.2,
+			expectedCoefficients: pok2.Vector{1 / 2.2},
+		},
+		"constant column": {
+			x:                    pok2.Matrix{{1, 7}, {2, 7}, {3, 7}, {4, 7}, {5, 7}},
+			y:                    pok2.Vector{2, 4, 5, 4, 5},
+			lambda:               0.2,
+			alpha:                1,
+			expectedIntercept:    2.5,
+			expectedCoefficients: pok2.Vector{0.5, 0},
+		},
+		"alpha out of range": {
+			x:             pok2.Matrix{{1}, {2}},
+			y:             pok2.Vector{1, 2},
+			lambda:        1,
+			alpha:         1.5,
+			expectedError: fmt.Errorf("Parametr alpha musi należeć do przedziału [0, 1]"),
+		},
+		"negative lambda": {
+			x:             pok2.Matrix{{1}, {2}},
+			y:             pok2.Vector{1, 2},
+			lambda:        -0.1,
+			alpha:         1,
+			expectedError: fmt.Errorf("Parametr regularyzacji lambda nie może być ujemny"),
+		},
+		"empty x": {
+			x:             pok2.Matrix{},
+			y:             pok2.Vector{},
+			lambda:        1,
+			alpha:         1,
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := regression.ElasticNet(c.x, c.y, c.lambda, c.alpha)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				assert.Nil(t, m)
+				return
+			}
+
+			assert.InDelta(t, c.expectedIntercept, m.Intercept, 1e-10)
+			assert.True(t, c.expectedCoefficients.IsSimilar(m.Coefficients, 1e-10))
+			assert.True(t, m.Converged)
+			assertOptimal(t, c.x, c.y, m)
+		})
+	}
+}
+
+func TestLassoPath(t *testing.T) {
+	path, err := regression.LassoPath(wideX, wideY, nil)
+	assert.Nil(t, err)
+	assert.Len(t, path, 100)
+
+	// Największa lambda zeruje wszystkie współczynniki, a model sprowadza się do średniej y
+	assert.True(t, pok2.Vector{0, 0, 0, 0, 0}.IsSimilar(path[0].Coefficients, 1e-12))
+	assert.InDelta(t, 2.5, path[0].Intercept, 1e-12)
+
+	for k, m := range path {
+		if k > 0 {
+			assert.True(t, m.Lambda < path[k-1].Lambda)
+		}
+		assert.True(t, m.Converged)
+		assertOptimal(t, wideX, wideY, m)
+	}
+	assert.InDelta(t, path[0].Lambda*1e-3, path[99].Lambda, 1e-15)
+
+	single, err := regression.Lasso(wideX, wideY, path[50].Lambda)
+	assert.Nil(t, err)
+	assert.True(t, path[50].Coefficients.IsSimilar(single.Coefficients, 1e-8))
+
+	_, err = regression.ElasticNetPath(wideX, wideY, 0.5, pok2.Vector{1, -1})
+	assert.Equal(t, fmt.Errorf("Parametr regularyzacji lambda nie może być ujemny"), err)
+}
+
+func TestElasticNetPathCorrelatedColumns(t *testing.T) {
+	// Każda kolumna to wspólny czynnik f plus szum rzędu 0.01, więc kolumny są silnie skorelowane.
+	// Generator ma stałe ziarno, aby wynik testu był powtarzalny.
+	const n, p = 50, 30
+	rnd := rand.New(rand.NewSource(1))
+	x := make(pok2.Matrix, n)
+	y := make(pok2.Vector, n)
+	for i := range x {
+		f := rnd.NormFloat64()
+		x[i] = make(pok2.Vector, p)
+		for j := range x[i] {
+			x[i][j] = f + 0.01*rnd.NormFloat64()
+		}
+		y[i] = 3*f + 0.1*rnd.NormFloat64()
+	}
+
+	for _, alpha := range []float64{1, 0.5} {
+		path, err := regression.ElasticNetPath(x, y, alpha, nil)
+		assert.Nil(t, err)
+		assert.Len(t, path, 100)
+
+		for _, m := range path {
+			assert.True(t, m.Converged)
+			assert.True(t, m.Iterations > 0)
+			assertOptimal(t, x, y, m)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package regression
+
+import (
+	"fmt"
+
+	"github.com/53jk1/pok2"
+)
+
+// Polynomial przechowuje wynik dopasowania wielomianu metodą najmniejszych kwadratów.
+// Coefficients[k] jest współczynnikiem przy x^k.
+type Polynomial struct {
+	Coefficients pok2.Vector
+	// Residuals[i] = y[i] - Predict(x[i])
+	Residuals pok2.Vector
+	// RSquared to współczynnik determinacji 1 - RSS/TSS
+	RSquared float64
+	// StdErrors[k] to błąd standardowy współczynnika k; NaN, jeśli punktów jest tyle co współczynników
+	StdErrors pok2.Vector
+}
+
+// PolyFit dopasowuje wielomian stopnia degree do punktów (x[i], y[i]) metodą najmniejszych kwadratów.
+// Macierz Vandermonde'a rozwiązywana jest tym samym rozkładem QR, którego używa LeftDivide, ale przez
+// funkcję fit wspólną z OLS: fit rozkłada macierz sama, aby czynnik R posłużył też do wyznaczenia błędów
+// standardowych bez drugiego rozkładu i bez odwracania A^T A, co zawodzi np. dla x rzędu 1e-6.
+// Zwraca błąd, jeśli rozmiary X i Y nie są zgodne, stopień jest ujemny, punktów jest za mało
+// lub wartości X nie wyznaczają jednoznacznie wielomianu (*pok2.RankError).
+func PolyFit(x, y []float64, degree int) (*Polynomial, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("Rozmiary X i Y nie pasują")
+	}
+	if degree < 0 {
+		return nil, fmt.Errorf("Stopień wielomianu nie może być ujemny")
+	}
+	if len(x) < degree+1 {
+		return nil, fmt.Errorf("Do dopasowania wielomianu stopnia %d potrzeba co najmniej %d punktów", degree, degree+1)
+	}
+
+	a, err := Vandermonde(x, degree)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := fit(a, y, true)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Polynomial{
+		Coefficients: r.Coefficients,
+		Residuals:    r.Residuals,
+		RSquared:     r.RSquared,
+		StdErrors:    r.StdErrors,
+	}
+	return p, nil
+}
+
+// Vandermonde zwraca macierz o wierszach 1, x[i], x[i]^2, ..., x[i]^degree.
+func Vandermonde(x []float64, degree int) (pok2.Matrix, error) {
+	if len(x) == 0 {
+		return nil, pok2.ErrEmptyMatrix
+	}
+
+	a := make(pok2.Matrix, len(x))
+	for i := range a {
+		a[i] = pok2.Vector{1}
+	}
+
+	var err error
+	for k := 1; k <= degree; k++ {
+		a, err = a.InsertCol(k, pok2.Vector(x).Power(float64(k)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Predict zwraca wartość wielomianu w punkcie x, wyznaczoną schematem Hornera.
+func (p *Polynomial) Predict(x float64) float64 {
+	var r float64
+	for k := len(p.Coefficients) - 1; k >= 0; k-- {
+		r = r*x + p.Coefficients[k]
+	}
+	return r
+}
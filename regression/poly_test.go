@@ -0,0 +1,128 @@
+package regression_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/53jk1/pok2/regression"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolyFit(t *testing.T) {
+	cases := map[string]struct {
+		x                    []float64
+		y                    []float64
+		degree               int
+		expectedCoefficients pok2.Vector
+		expectedResiduals    pok2.Vector
+		expectedRSquared     float64
+		expectedStdErrors    pok2.Vector
+		expectedError        error
+	}{
+		"simple linear regression": {
+			x:                    []float64{1, 2, 3, 4, 5},
+			y:                    []float64{2, 4, 5, 4, 5},
+			degree:               1,
+			expectedCoefficients: pok2.Vector{2.2, 0.6},
+			expectedResiduals:    pok2.Vector{-0.8, 0.6, 1, -0.6, -0.2},
+			expectedRSquared:     0.6,
+			expectedStdErrors:    pok2.Vector{math.Sqrt(0.88), math.Sqrt(0.08)},
+			expectedError:        nil,
+		},
+		"quadratic without noise": {
+			x:                    []float64{-2, -1, 0, 1, 2, 3},
+			y:                    []float64{7, 3.5, 1, -0.5, -1, -0.5},
+			degree:               2,
+			expectedCoefficients: pok2.Vector{1, -2, 0.5},
+			expectedResiduals:    pok2.Vector{0, 0, 0, 0, 0, 0},
+			expectedRSquared:     1,
+			expectedStdErrors:    pok2.Vector{0, 0, 0},
+			expectedError:        nil,
+		},
+		"constant": {
+			x:                    []float64{1, 2, 3, 4},
+			y:                    []float64{1, 2, 2, 3},
+			degree:               0,
+			expectedCoefficients: pok2.Vector{2},
+			expectedResiduals:    pok2.Vector{-1, 0, 0, 1},
+			expectedRSquared:     0,
+			expectedStdErrors:    pok2.Vector{math.Sqrt(2.0 / 3 / 4)},
+			expectedError:        nil,
+		},
+		"wrong x and y size": {
+			x:             []float64{1, 2, 3},
+			y:             []float64{1, 2},
+			degree:        1,
+			expectedError: fmt.Errorf("Rozmiary X i Y nie pasują"),
+		},
+		"negative degree": {
+			x:             []float64{1, 2, 3},
+			y:             []float64{1, 2, 3},
+			degree:        -1,
+			expectedError: fmt.Errorf("Stopień wielomianu nie może być ujemny"),
+		},
+		"too few points": {
+			x:             []float64{1, 2, 3},
+			y:             []float64{1, 2, 3},
+			degree:        3,
+			expectedError: fmt.Errorf("Do dopasowania wielomianu stopnia 3 potrzeba co najmniej 4 punktów"),
+		},
+		"same x values": {
+			x:             []float64{2, 2, 2},
+			y:             []float64{1, 2, 3},
+			degree:        1,
+			expectedError: &pok2.RankError{Rank: 1, Cols: 2},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := regression.PolyFit(c.x, c.y, c.degree)
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				assert.Nil(t, p)
+				return
+			}
+
+			assert.True(t, c.expectedCoefficients.IsSimilar(p.Coefficients, 1e-10))
+			assert.True(t, c.expectedResiduals.IsSimilar(p.Residuals, 1e-10))
+			assert.InDelta(t, c.expectedRSquared, p.RSquared, 1e-10)
+			assert.True(t, c.expectedStdErrors.IsSimilar(p.StdErrors, 1e-7))
+		})
+	}
+}
+
+func TestPolyFitExactInterpolation(t *testing.T) {
+	p, err := regression.PolyFit([]float64{0, 1, 3}, []float64{1, 0, 4}, 2)
+	assert.Nil(t, err)
+
+	for i, x := range []float64{0, 1, 3} {
+		assert.InDelta(t, []float64{1, 0, 4}[i], p.Predict(x), 1e-12)
+	}
+	assert.InDelta(t, 1, p.RSquared, 1e-12)
+	for _, se := range p.StdErrors {
+		assert.True(t, math.IsNaN(se))
+	}
+}
+
+func TestPolyFitSmallScale(t *testing.T) {
+	// Dane z przypadku "simple linear regression" z x przeskalowanym o 1e-6; A^T A jest tu numerycznie pojedyncza
+	p, err := regression.PolyFit([]float64{1e-6, 2e-6, 3e-6, 4e-6, 5e-6}, []float64{2, 4, 5, 4, 5}, 1)
+	assert.Nil(t, err)
+
+	assert.InDelta(t, 2.2, p.Coefficients[0], 1e-9)
+	assert.InDelta(t, 0.6e6, p.Coefficients[1], 1e-3)
+	assert.InDelta(t, math.Sqrt(0.88), p.StdErrors[0], 1e-9)
+	assert.InDelta(t, math.Sqrt(0.08)*1e6, p.StdErrors[1], 1e-3)
+}
+
+func TestVandermonde(t *testing.T) {
+	a, err := regression.Vandermonde([]float64{2, -1, 0.5}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, pok2.Matrix{{1, 2, 4, 8}, {1, -1, 1, -1}, {1, 0.5, 0.25, 0.125}}, a)
+
+	_, err = regression.Vandermonde([]float64{}, 2)
+	assert.Equal(t, pok2.ErrEmptyMatrix, err)
+}
@@ -0,0 +1,232 @@
+package pok2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SVD przechowuje rozkład według wartości osobliwych macierzy A (m x n) w postaci A = U * diag(Sigma) * VT.
+// U ma wymiary m x k, Sigma zawiera k wartości osobliwych uporządkowanych malejąco, a VT ma wymiary k x n, gdzie k = min(m, n).
+// Kolumny U odpowiadające zerowym wartościom osobliwym są zerowe.
+type SVD struct {
+	U     Matrix
+	Sigma Vector
+	VT    Matrix
+}
+
+// maxJacobiSweeps ogranicza liczbę przebiegów metody Jacobiego, która w praktyce zbiega w kilkunastu.
+const maxJacobiSweeps = 100
+
+// SVD zwraca rozkład według wartości osobliwych obliczony jednostronną metodą Jacobiego oraz błąd (jeśli istnieje).
+// Błąd jest zwracany także wtedy, gdy metoda nie zbiegnie w maxJacobiSweeps przebiegach (np. dla elementów NaN).
+func (m Matrix) SVD() (*SVD, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	rows, cols := m.Dim()
+
+	// Metoda wymaga co najmniej tylu wierszy co kolumn, więc macierz szeroką rozkładamy po transpozycji
+	if rows < cols {
+		mT, err := m.Transpose()
+		if err != nil {
+			return nil, err
+		}
+		t, err := mT.SVD()
+		if err != nil {
+			return nil, err
+		}
+		u, err := t.VT.Transpose()
+		if err != nil {
+			return nil, err
+		}
+		vT, err := t.U.Transpose()
+		if err != nil {
+			return nil, err
+		}
+		return &SVD{U: u, Sigma: t.Sigma, VT: vT}, nil
+	}
+
+	u := m.copy()
+	v := identity(cols)
+
+	// Kolumny o normie poniżej tego progu są szumem zaokrągleń i traktujemy je jako zerowe
+	// NormFrobenius nie zwraca błędu, bo macierz została już sprawdzona przez Validate
+	frobenius, _ := m.NormFrobenius()
+	negligible := eps * frobenius
+
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps && !converged; sweep++ {
+		rotated := false
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < rows; i++ {
+					alpha += u[i][p] * u[i][p]
+					beta += u[i][q] * u[i][q]
+					gamma += u[i][p] * u[i][q]
+				}
+
+				if gamma == 0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) ||
+					math.Sqrt(alpha) <= negligible || math.Sqrt(beta) <= negligible {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				rotateColumns(u, p, q, c, s)
+				rotateColumns(v, p, q, c, s)
+			}
+		}
+		converged = !rotated
+	}
+	if !converged {
+		return nil, fmt.Errorf("Metoda Jacobiego nie zbiegła po %d przebiegach", maxJacobiSweeps)
+	}
+
+	sigma := make(Vector, cols)
+	for j := 0; j < cols; j++ {
+		var norm float64
+		for i := 0; i < rows; i++ {
+			norm = math.Hypot(norm, u[i][j])
+		}
+		sigma[j] = norm
+		for i := 0; i < rows; i++ {
+			if norm > 0 {
+				u[i][j] /= norm
+			} else {
+				u[i][j] = 0
+			}
+		}
+	}
+
+	order := make([]int, cols)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return sigma[order[i]] > sigma[order[j]]
+	})
+
+	result := &SVD{
+		U:     make(Matrix, rows),
+		Sigma: make(Vector, cols),
+		VT:    make(Matrix, cols),
+	}
+	for i := 0; i < rows; i++ {
+		result.U[i] = make(Vector, cols)
+		for j, o := range order {
+			result.U[i][j] = u[i][o]
+		}
+	}
+	for j, o := range order {
+		result.Sigma[j] = sigma[o]
+		result.VT[j] = make(Vector, cols)
+		for i := 0; i < cols; i++ {
+			result.VT[j][i] = v[i][o]
+		}
+	}
+
+	return result, nil
+}
+
+// rotateColumns stosuje obrót Givensa do kolumn p i q macierzy.
+func rotateColumns(a Matrix, p, q int, c, s float64) {
+	for i := range a {
+		ap, aq := a[i][p], a[i][q]
+		a[i][p] = c*ap - s*aq
+		a[i][q] = s*ap + c*aq
+	}
+}
+
+// tolerance zwraca próg, poniżej którego wartości osobliwe traktowane są jako zerowe.
+// Dla tol <= 0 używany jest domyślny próg max(m, n) * eps * największa wartość osobliwa.
+func (f *SVD) tolerance(tol float64) float64 {
+	if tol > 0 {
+		return tol
+	}
+	rows, _ := f.U.Dim()
+	_, cols := f.VT.Dim()
+	if cols > rows {
+		rows = cols
+	}
+	return float64(rows) * eps * f.Sigma[0]
+}
+
+// Rank zwraca liczbę wartości osobliwych większych od tolerancji.
+func (f *SVD) Rank(tol float64) int {
+	tol = f.tolerance(tol)
+	rank := 0
+	for _, s := range f.Sigma {
+		if s > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+// PseudoInverse zwraca pseudoodwrotność Moore'a-Penrose'a V * diag(1/Sigma) * U^T,
+// pomijając wartości osobliwe nie większe od tolerancji.
+func (f *SVD) PseudoInverse(tol float64) Matrix {
+	tol = f.tolerance(tol)
+	rows, _ := f.U.Dim()
+	_, cols := f.VT.Dim()
+
+	p := make(Matrix, cols)
+	for i := range p {
+		p[i] = make(Vector, rows)
+		for j := 0; j < rows; j++ {
+			for k, s := range f.Sigma {
+				if s > tol {
+					p[i][j] += f.VT[k][i] * f.U[j][k] / s
+				}
+			}
+		}
+	}
+	return p
+}
+
+// PseudoInverse otrzymuje tolerancję jako parametr.
+// Zwraca pseudoodwrotność Moore'a-Penrose'a macierzy oraz błąd (jeśli istnieje).
+// Wartości osobliwe nie większe od tolerancji traktowane są jako zerowe; dla tol <= 0 używany jest próg domyślny.
+// Iloczyn pseudoodwrotności i B daje rozwiązanie o minimalnej normie również dla układów o niepełnym rzędzie.
+func (m Matrix) PseudoInverse(tol float64) (Matrix, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return nil, err
+	}
+	return svd.PseudoInverse(tol), nil
+}
+
+// Rank otrzymuje tolerancję jako parametr.
+// Zwraca rząd macierzy wyznaczony z wartości osobliwych oraz błąd (jeśli istnieje). Dla tol <= 0 używany jest próg domyślny.
+func (m Matrix) Rank(tol float64) (int, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return 0, err
+	}
+	return svd.Rank(tol), nil
+}
+
+// ConditionNumber zwraca wskaźnik uwarunkowania macierzy w normie spektralnej, czyli iloraz największej i najmniejszej wartości osobliwej, oraz błąd (jeśli istnieje).
+// Dla macierzy osobliwej zwracana jest dodatnia nieskończoność.
+func (m Matrix) ConditionNumber() (float64, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return 0, err
+	}
+
+	smallest := svd.Sigma[len(svd.Sigma)-1]
+	if smallest == 0 {
+		return math.Inf(1), nil
+	}
+	return svd.Sigma[0] / smallest, nil
+}
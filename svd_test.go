@@ -0,0 +1,202 @@
+package pok2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/53jk1/pok2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixSVD(t *testing.T) {
+	cases := map[string]struct {
+		matrix        pok2.Matrix
+		expectedSigma pok2.Vector
+		expectedError error
+	}{
+		"square svd": {
+			matrix: pok2.Matrix{
+				{3, 0},
+				{4, 5},
+			},
+			expectedSigma: pok2.Vector{3 * math.Sqrt(5), math.Sqrt(5)},
+			expectedError: nil,
+		},
+		"tall svd": {
+			matrix: pok2.Matrix{
+				{2, 0},
+				{0, -3},
+				{0, 0},
+			},
+			expectedSigma: pok2.Vector{3, 2},
+			expectedError: nil,
+		},
+		"wide svd": {
+			matrix: pok2.Matrix{
+				{3, 2, 2},
+				{2, 3, -2},
+			},
+			expectedSigma: pok2.Vector{5, 3},
+			expectedError: nil,
+		},
+		"rank deficient svd": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{2, 4},
+			},
+			expectedSigma: pok2.Vector{5, 0},
+			expectedError: nil,
+		},
+		"svd of empty matrix": {
+			matrix:        pok2.Matrix{},
+			expectedError: pok2.ErrEmptyMatrix,
+		},
+		"svd does not converge with NaN": {
+			matrix: pok2.Matrix{
+				{1, math.NaN()},
+				{2, 3},
+			},
+			expectedError: fmt.Errorf("Metoda Jacobiego nie zbiegła po 100 przebiegach"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			svd, err := c.matrix.SVD()
+			assert.Equal(t, c.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, true, svd.Sigma.IsSimilar(c.expectedSigma, 1e-10))
+
+			us := make(pok2.Matrix, len(svd.U))
+			for i := range svd.U {
+				us[i] = make(pok2.Vector, len(svd.Sigma))
+				for j := range svd.Sigma {
+					us[i][j] = svd.U[i][j] * svd.Sigma[j]
+				}
+			}
+			product, _ := us.MultiplyBy(svd.VT)
+			assert.Equal(t, true, product.IsSimilar(c.matrix, 1e-10))
+		})
+	}
+}
+
+func TestMatrixPseudoInverse(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedResult pok2.Matrix
+	}{
+		"pseudo-inverse of invertible matrix": {
+			matrix: pok2.Matrix{
+				{4, 7},
+				{2, 6},
+			},
+			expectedResult: pok2.Matrix{
+				{0.6, -0.7},
+				{-0.2, 0.4},
+			},
+		},
+		"pseudo-inverse of singular matrix": {
+			matrix: pok2.Matrix{
+				{1, 2},
+				{2, 4},
+			},
+			expectedResult: pok2.Matrix{
+				{0.04, 0.08},
+				{0.08, 0.16},
+			},
+		},
+		"pseudo-inverse of wide matrix": {
+			matrix: pok2.Matrix{
+				{1, 0, 0},
+				{0, 2, 0},
+			},
+			expectedResult: pok2.Matrix{
+				{1, 0},
+				{0, 0.5},
+				{0, 0},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			pinv, err := c.matrix.PseudoInverse(0)
+			assert.Nil(t, err)
+			assert.Equal(t, true, pinv.IsSimilar(c.expectedResult, 1e-10))
+		})
+	}
+}
+
+func TestMatrixRank(t *testing.T) {
+	cases := map[string]struct {
+		matrix       pok2.Matrix
+		tol          float64
+		expectedRank int
+	}{
+		"full rank matrix": {
+			matrix: pok2.Matrix{
+				{4, 7},
+				{2, 6},
+			},
+			expectedRank: 2,
+		},
+		"rank deficient matrix": {
+			matrix: pok2.Matrix{
+				{1, 2, 3},
+				{2, 4, 6},
+				{1, 1, 1},
+			},
+			expectedRank: 2,
+		},
+		"rank with explicit tolerance": {
+			matrix: pok2.Matrix{
+				{1, 0},
+				{0, 1e-6},
+			},
+			tol:          1e-3,
+			expectedRank: 1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rank, err := c.matrix.Rank(c.tol)
+			assert.Nil(t, err)
+			assert.Equal(t, c.expectedRank, rank)
+		})
+	}
+}
+
+func TestMatrixConditionNumber(t *testing.T) {
+	cases := map[string]struct {
+		matrix         pok2.Matrix
+		expectedResult float64
+	}{
+		"diagonal matrix": {
+			matrix: pok2.Matrix{
+				{10, 0},
+				{0, 0.1},
+			},
+			expectedResult: 100,
+		},
+		"singular matrix": {
+			matrix: pok2.Matrix{
+				{1, 0},
+				{0, 0},
+			},
+			expectedResult: math.Inf(1),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cond, err := c.matrix.ConditionNumber()
+			assert.Nil(t, err)
+			assert.Equal(t, c.expectedResult, cond)
+		})
+	}
+}